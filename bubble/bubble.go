@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 
+	composerview "clx/bubble/composer"
 	"clx/bubble/list"
+	"clx/composer"
 	"clx/favorites"
+	"clx/hn"
 	"clx/settings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -35,10 +38,44 @@ func (m model) View() string {
 	return docStyle.Render(m.list.View())
 }
 
-func Run(config *settings.Config) {
+func Run(config *settings.Config, service hn.Service) {
+	run(list.New(list.NewDefaultDelegate(), config, service, loadFavorites(), 0, 0))
+}
+
+// RunProfile starts on the profile of the given user instead of the front
+// page.
+func RunProfile(config *settings.Config, service hn.Service, username string) {
+	l := list.New(list.NewDefaultDelegate(), config, service, loadFavorites(), 0, 0)
+	l.SetStartupUser(username)
+
+	run(l)
+}
+
+// RunComposer writes, previews and posts a reply or submission without the
+// main view.
+func RunComposer(config *settings.Config, draft *composer.Draft) {
+	p := tea.NewProgram(composerview.New(config, draft))
+
+	if _, err := p.Run(); err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+}
+
+func loadFavorites() *favorites.Favorites {
+	fav, err := favorites.New()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return fav
+}
+
+func run(l list.Model) {
 	cli.ClearScreen()
 
-	m := model{list: list.New(list.NewDefaultDelegate(), config, favorites.New(), 0, 0)}
+	m := model{list: l}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
@@ -0,0 +1,126 @@
+package composer
+
+import (
+	"context"
+
+	"clx/composer"
+	"clx/session"
+	"clx/settings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type state int
+
+const (
+	editing state = iota
+	previewing
+	posting
+	done
+)
+
+// Model opens a draft in $EDITOR, shows a preview of it and posts it once
+// confirmed. It is used by the commands that post without the main view.
+type Model struct {
+	config  *settings.Config
+	draft   *composer.Draft
+	session *session.Session
+	state   state
+	text    string
+	status  string
+
+	// cancel stops posting when the user quits
+	cancel context.CancelFunc
+}
+
+func New(config *settings.Config, draft *composer.Draft) Model {
+	return Model{
+		config:  config,
+		draft:   draft,
+		session: &session.Session{BaseURL: config.HackerNewsURL},
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return Edit(m.draft)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case Edited:
+		text, status := ReadEdited(m.draft, msg)
+		if text == "" {
+			return m.quit(status)
+		}
+
+		m.text = text
+		m.state = previewing
+
+	case Posted:
+		m.cancel()
+
+		return m.quit(PostedStatus(m.draft, msg, "Posted"))
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			if m.state == posting {
+				m.cancel()
+
+				return m.quit("Posting cancelled. " + KeptStatus(m.draft))
+			}
+
+			return m.quit(KeptStatus(m.draft))
+		}
+
+		if m.state != previewing {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "y":
+			ctx, cancel := context.WithCancel(context.Background())
+
+			m.state = posting
+			m.cancel = cancel
+
+			return m, Post(ctx, m.draft, m.session, m.text)
+
+		case "e":
+			m.state = editing
+
+			return m, Edit(m.draft)
+
+		case "q", "esc":
+			return m.quit(KeptStatus(m.draft))
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) quit(status string) (tea.Model, tea.Cmd) {
+	m.state = done
+	m.status = status
+
+	return m, tea.Quit
+}
+
+func (m Model) View() string {
+	faint := lipgloss.NewStyle().Faint(true)
+
+	switch m.state {
+	case previewing:
+		return m.draft.Preview(m.text, m.config) + "\n" + faint.Render("y post • e edit • q quit and keep the draft") +
+			"\n"
+
+	case posting:
+		return faint.Render("Posting…") + "\n"
+
+	case done:
+		return m.status + "\n"
+
+	default:
+		return ""
+	}
+}
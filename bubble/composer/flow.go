@@ -0,0 +1,86 @@
+package composer
+
+import (
+	"context"
+	"time"
+
+	"clx/composer"
+	"clx/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PostTimeout bounds how long posting a draft may take
+const PostTimeout = 30 * time.Second
+
+// Edited is sent once the editor has been closed
+type Edited struct {
+	Err error
+}
+
+// Posted is sent once the draft has been posted or posting has failed
+type Posted struct {
+	Err error
+}
+
+// Edit opens the draft in $EDITOR and sends Edited once the editor is closed.
+func Edit(draft *composer.Draft) tea.Cmd {
+	command, err := draft.Edit()
+	if err != nil {
+		return func() tea.Msg {
+			return Edited{Err: err}
+		}
+	}
+
+	return tea.ExecProcess(command, func(err error) tea.Msg {
+		return Edited{Err: err}
+	})
+}
+
+// ReadEdited returns the text of the draft once it has been edited. If there
+// is nothing to post, the text is empty and the status says why. An empty
+// draft is removed.
+func ReadEdited(draft *composer.Draft, msg Edited) (text string, status string) {
+	if msg.Err != nil {
+		return "", "Could not open the editor: " + msg.Err.Error()
+	}
+
+	text, err := draft.Read()
+	if err != nil {
+		return "", err.Error()
+	}
+
+	if text == "" {
+		_ = draft.Remove()
+
+		return "", "Nothing to post, the draft is discarded"
+	}
+
+	return text, ""
+}
+
+// Post posts the text and sends Posted. Posting stops once ctx is done or
+// PostTimeout has passed.
+func Post(ctx context.Context, draft *composer.Draft, s *session.Session, text string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, PostTimeout)
+		defer cancel()
+
+		return Posted{Err: draft.Post(ctx, s, text)}
+	}
+}
+
+// PostedStatus describes the outcome of posting, with success as the status
+// if the draft has been posted.
+func PostedStatus(draft *composer.Draft, msg Posted, success string) string {
+	if msg.Err != nil {
+		return "Could not post: " + composer.ErrorMessage(msg.Err) + ". " + KeptStatus(draft)
+	}
+
+	return success
+}
+
+// KeptStatus is shown when leaving the composer without posting.
+func KeptStatus(draft *composer.Draft) string {
+	return "The draft is kept in " + draft.Path
+}
@@ -0,0 +1,120 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	"clx/bubble/list/message"
+	"clx/constants/style"
+	"clx/hn"
+	"clx/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const actionTimeout = 15 * time.Second
+
+// The lesskey file binds these keys in the comment section to quit less with
+// the key as the exit status
+var commentSectionActions = map[int]session.Action{
+	'U': session.Upvote,
+	'F': session.Favorite,
+	'X': session.Flag,
+}
+
+// getCommentSectionAction maps the exit status of less onto an action on the
+// story whose comment section was open.
+func getCommentSectionAction(id int, err error) tea.Msg {
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		if exitErr.ExitCode() == 'R' {
+			return message.ReplyRequested{Id: id}
+		}
+
+		if exitErr.ExitCode() == 'E' {
+			return message.ExportRequested{Id: id}
+		}
+
+		if action, ok := commentSectionActions[exitErr.ExitCode()]; ok {
+			return message.CommentSectionAction{Id: id, Action: action}
+		}
+	}
+
+	return message.EditorFinishedMsg{Err: err}
+}
+
+func (m *Model) performAction(id int, action session.Action) tea.Cmd {
+	s := &session.Session{BaseURL: m.config.HackerNewsURL}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		return message.ActionFinished{Action: action, Err: s.Do(ctx, id, action)}
+	}
+}
+
+func (m *Model) handleCommentSectionAction(msg message.CommentSectionAction) tea.Cmd {
+	m.SetIsVisible(true)
+	m.SetDisabledInput(false)
+
+	if msg.Action == session.Flag {
+		return m.showFlagPrompt()
+	}
+
+	return m.performAction(msg.Id, msg.Action)
+}
+
+func (m *Model) showFlagPrompt() tea.Cmd {
+	m.SetPermanentStatusMessage(getFlagConfirmationMessage(), false)
+	m.onFlagPrompt = true
+	m.disableInput = true
+
+	return nil
+}
+
+func getActionFinishedMessage(msg message.ActionFinished) string {
+	switch {
+	case errors.Is(msg.Err, session.ErrNotLoggedIn):
+		return "Not logged in, run 'clx login' first"
+
+	case errors.Is(msg.Err, session.ErrUnavailable) && msg.Action == session.Upvote:
+		return "Already upvoted or cannot be upvoted"
+
+	case errors.Is(msg.Err, session.ErrUnavailable) && msg.Action == session.Favorite:
+		return "Already a favorite on Hacker News"
+
+	case errors.Is(msg.Err, session.ErrUnavailable):
+		return "Already flagged or not enough karma to flag"
+
+	case msg.Err != nil:
+		return hn.ErrorMessage(msg.Err)
+
+	case msg.Action == session.Upvote:
+		return "Upvoted"
+
+	case msg.Action == session.Favorite:
+		return "Added to favorites on Hacker News"
+
+	default:
+		return "Flagged"
+	}
+}
+
+func getFlagConfirmationMessage() string {
+	normal := lipgloss.NewStyle().
+		Foreground(style.GetUnselectedItemFg()).
+		Background(style.GetStatusBarBg())
+	red := normal.Copy().
+		Foreground(lipgloss.Color("1"))
+	bold := normal.Copy().
+		Foreground(style.GetBlue()).
+		Bold(true)
+
+	return red.Render("Flag") + normal.Render(" on Hacker News? Press ") + bold.Render("y") +
+		normal.Render(" to confirm")
+}
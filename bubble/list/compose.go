@@ -0,0 +1,91 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	composerview "clx/bubble/composer"
+	"clx/bubble/list/message"
+	"clx/cli"
+	"clx/composer"
+	"clx/constants/style"
+	"clx/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// composeReply opens a reply to the item in $EDITOR. Afterwards the reply is
+// shown in less and posted once confirmed in the status bar.
+func (m *Model) composeReply(id int) tea.Cmd {
+	m.draft = composer.NewReply(id)
+
+	m.SetIsVisible(false)
+	m.SetDisabledInput(true)
+
+	return composerview.Edit(m.draft)
+}
+
+func (m *Model) handleDraftEdited(msg composerview.Edited) tea.Cmd {
+	text, status := composerview.ReadEdited(m.draft, msg)
+	if text == "" {
+		return m.leaveComposer(status)
+	}
+
+	m.draftText = text
+
+	command := cli.Less(m.draft.Preview(text, m.config), m.config)
+
+	return tea.ExecProcess(command, func(err error) tea.Msg {
+		return message.DraftPreviewed{}
+	})
+}
+
+func (m *Model) showPostPrompt() tea.Cmd {
+	m.SetIsVisible(true)
+	m.SetPermanentStatusMessage(getPostConfirmationMessage(), false)
+	m.onPostPrompt = true
+
+	return nil
+}
+
+func (m *Model) postDraft() tea.Cmd {
+	draft, text := m.draft, m.draftText
+	s := &session.Session{BaseURL: m.config.HackerNewsURL}
+
+	m.onPostPrompt = false
+	m.hideStatusMessage()
+
+	return tea.Batch(m.StartSpinner(), composerview.Post(context.Background(), draft, s, text))
+}
+
+func (m *Model) handleDraftPosted(msg composerview.Posted) tea.Cmd {
+	m.StopSpinner()
+
+	return m.leaveComposer(composerview.PostedStatus(m.draft, msg, "Reply posted"))
+}
+
+func (m *Model) leaveComposer(status string) tea.Cmd {
+	m.onPostPrompt = false
+	m.draft = nil
+	m.draftText = ""
+
+	m.SetIsVisible(true)
+	m.SetDisabledInput(false)
+
+	return m.NewStatusMessageWithDuration(status, time.Second*3)
+}
+
+func getPostConfirmationMessage() string {
+	normal := lipgloss.NewStyle().
+		Foreground(style.GetUnselectedItemFg()).
+		Background(style.GetStatusBarBg())
+	green := normal.Copy().
+		Foreground(lipgloss.Color("2"))
+	bold := normal.Copy().
+		Foreground(style.GetBlue()).
+		Bold(true)
+
+	return green.Render("Post") + normal.Render(" reply? Press ") + bold.Render("y") +
+		normal.Render(" to confirm or ") + bold.Render("e") + normal.Render(" to edit")
+}
@@ -129,6 +129,13 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item *item.Item
 	comments := getComments(item.CommentsCount, enableNerdFonts)
 	time := parseTime(item.Time, enableNerdFonts)
 
+	// Job postings can't be voted on or commented on
+	if item.Type == "job" {
+		score = getScore(0, enableNerdFonts)
+		author = getAuthor("", enableNerdFonts)
+		comments = getComments(0, enableNerdFonts)
+	}
+
 	if enableNerdFonts {
 		spacingSize := 2
 		spacing := strings.Repeat(" ", spacingSize)
@@ -150,11 +157,11 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item *item.Item
 	)
 
 	switch {
-	case isSelected && m.onAddToFavoritesPrompt:
+	case isSelected && (m.onAddToFavoritesPrompt || m.onPostPrompt):
 		title, desc = styleTitleAndDesc(title, s.SelectedTitleAddToFavorites, s.SelectedDescAddToFavorites, domain,
 			desc, syntax.AddToFavorites, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
-	case isSelected && m.onRemoveFromFavoritesPrompt:
+	case isSelected && (m.onRemoveFromFavoritesPrompt || m.onFlagPrompt):
 		title, desc = styleTitleAndDesc(title, s.SelectedTitleRemoveFromFavorites, s.SelectedDescRemoveFromFavoritesFavorites, domain,
 			desc, syntax.RemoveFromFavorites, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
@@ -166,7 +173,8 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item *item.Item
 		title, desc = styleTitleAndDesc(title, s.MarkAsReadTitle.Italic(true), s.MarkAsReadDesc, domain,
 			desc, syntax.MarkAsRead, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
-	case m.disableInput && !(m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt):
+	case m.disableInput && !(m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt || m.onFlagPrompt ||
+		m.onPostPrompt):
 		title, desc = styleTitleAndDesc(title, s.MarkAsReadTitle.Italic(false), s.MarkAsReadDesc, domain,
 			desc, syntax.MarkAsRead, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
@@ -0,0 +1,54 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"clx/bubble/list/message"
+	"clx/export"
+	"clx/hn"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportThread fetches the comments of the item and writes them as Markdown
+// to the current directory, next to any earlier exports of the item.
+func (m *Model) exportThread(id int) tea.Cmd {
+	return func() tea.Msg {
+		story, err := m.service.FetchComments(context.Background(), id)
+		if err != nil {
+			return message.ExportFinished{Err: err}
+		}
+
+		directory, err := os.Getwd()
+		if err != nil {
+			return message.ExportFinished{Err: err}
+		}
+
+		file, err := export.Create(directory, id, export.FormatMarkdown)
+		if err != nil {
+			return message.ExportFinished{Err: fmt.Errorf("could not create export: %w", err)}
+		}
+
+		if err := export.Write(file, story, export.FormatMarkdown); err != nil {
+			_ = file.Close()
+
+			return message.ExportFinished{Err: err}
+		}
+
+		if err := file.Close(); err != nil {
+			return message.ExportFinished{Err: fmt.Errorf("could not write %s: %w", file.Name(), err)}
+		}
+
+		return message.ExportFinished{Path: file.Name()}
+	}
+}
+
+func getExportFinishedMessage(msg message.ExportFinished) string {
+	if msg.Err != nil {
+		return "Could not export: " + hn.ErrorMessage(msg.Err)
+	}
+
+	return "Exported to " + msg.Path
+}
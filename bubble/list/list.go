@@ -1,7 +1,9 @@
 package list
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -15,9 +17,11 @@ import (
 	"clx/reader"
 
 	"clx/browser"
+	composerview "clx/bubble/composer"
 	"clx/bubble/list/message"
 	"clx/bubble/ranking"
 	"clx/cli"
+	"clx/composer"
 	"clx/constants/category"
 	"clx/constants/style"
 	"clx/favorites"
@@ -25,10 +29,9 @@ import (
 	"clx/help"
 	"clx/history"
 	"clx/hn"
-	"clx/hn/services/hybrid"
-	"clx/hn/services/mock"
 	"clx/item"
 	"clx/screen"
+	"clx/session"
 	"clx/settings"
 	"clx/tree"
 	"clx/validator"
@@ -40,7 +43,7 @@ import (
 )
 
 const (
-	numberOfCategories = 5
+	numberOfCategories = 8
 )
 
 // Item is an item that appears in the list.
@@ -88,6 +91,8 @@ type Model struct {
 	isVisible                   bool
 	onAddToFavoritesPrompt      bool
 	onRemoveFromFavoritesPrompt bool
+	onFlagPrompt                bool
+	onPostPrompt                bool
 
 	StatusMessageLifetime time.Duration
 
@@ -104,19 +109,61 @@ type Model struct {
 	service   hn.Service
 	favorites *favorites.Favorites
 
+	cancelFetch context.CancelFunc
+	isOffline   bool
+
+	isFetchingMore bool
+	isExhausted    []bool
+	newStories     []int
+
+	search      search
+	startupUser string
+
+	draft     *composer.Draft
+	draftText string
+
 	isOnHelpScreen bool
 	viewport       viewport.Model
+
+	isOnSettingsScreen bool
+	settingsCursor     int
 }
 
 func (m *Model) FetchFrontPageStories() tea.Cmd {
+	ctx := m.newFetchContext()
+
 	return func() tea.Msg {
 		itemsToFetch := m.getNumberOfItemsToFetch(m.category)
 
-		stories, errMsg := m.service.FetchItems(itemsToFetch, category.FrontPage)
+		stories, err := m.service.FetchItems(ctx, 0, itemsToFetch, category.FrontPage)
 
 		m.items[category.FrontPage] = stories
 
-		return message.FetchingFinished{Message: errMsg}
+		return message.FetchingFinished{Offline: m.isServiceOffline(), Err: err}
+	}
+}
+
+func (m *Model) isServiceOffline() bool {
+	offlineReporter, ok := m.service.(hn.OfflineReporter)
+
+	return ok && offlineReporter.IsOffline()
+}
+
+// newFetchContext cancels any fetch that is still in flight and returns the
+// context for the next one.
+func (m *Model) newFetchContext() context.Context {
+	m.cancelInFlightFetch()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFetch = cancel
+
+	return ctx
+}
+
+func (m *Model) cancelInFlightFetch() {
+	if m.cancelFetch != nil {
+		m.cancelFetch()
+		m.cancelFetch = nil
 	}
 }
 
@@ -134,12 +181,17 @@ func (m *Model) getNumberOfItemsToFetch(cat int) int {
 	case category.Show:
 		return m.Paginator.PerPage
 
+	case category.Best:
+		return m.Paginator.PerPage * 3
+
 	default:
 		return m.Paginator.PerPage
 	}
 }
 
-func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Favorites, width, height int) Model {
+func New(delegate ItemDelegate, config *settings.Config, service hn.Service, favorites *favorites.Favorites,
+	width, height int,
+) Model {
 	styles := DefaultStyles()
 
 	sp := spinner.New()
@@ -156,8 +208,8 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 	p.UsePgUpPgDownKeys = false
 	p.UseUpDownKeys = false
 
-	bufferCategory := 1
-	items := make([][]*item.Item, numberOfCategories+bufferCategory)
+	bufferAndSearchCategories := 2
+	items := make([][]*item.Item, numberOfCategories+bufferAndSearchCategories)
 
 	m := Model{
 		showTitle:             true,
@@ -169,15 +221,17 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 		width:        width,
 		height:       height,
 		delegate:     delegate,
-		history:      getHistory(config.DebugMode, config.DoNotMarkSubmissionsAsRead),
+		history:      history.New(config.DebugMode, config.DoNotMarkSubmissionsAsRead),
 		items:        items,
+		isExhausted:  make([]bool, numberOfCategories+bufferAndSearchCategories),
+		newStories:   make([]int, numberOfCategories+bufferAndSearchCategories),
 		Paginator:    p,
 		spinner:      sp,
 		onStartup:    true,
 		isVisible:    true,
 		disableInput: true,
 		config:       config,
-		service:      getService(config.DebugMode),
+		service:      service,
 		favorites:    favorites,
 	}
 
@@ -186,26 +240,6 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 	return m
 }
 
-func getHistory(debugMode bool, doNotMarkAsRead bool) history.History {
-	if debugMode {
-		return history.NewMockHistory()
-	}
-
-	if doNotMarkAsRead {
-		return history.NewNonPersistentHistory()
-	}
-
-	return history.NewPersistentHistory()
-}
-
-func getService(debugMode bool) hn.Service {
-	if debugMode {
-		return mock.Service{}
-	}
-
-	return &hybrid.Service{}
-}
-
 // SetShowTitle shows or hides the title bar.
 func (m *Model) SetShowTitle(v bool) {
 	m.showTitle = v
@@ -302,6 +336,10 @@ func (m *Model) CursorDown() {
 }
 
 func (m *Model) getNextCategory() int {
+	if m.category == category.Search {
+		return m.search.categoryBeforeSearch
+	}
+
 	isAtLastCategory := m.category == m.getNumberOfCategories()-1
 	if isAtLastCategory {
 		return category.FrontPage
@@ -319,13 +357,17 @@ func (m *Model) getNumberOfCategories() int {
 }
 
 func (m *Model) getPrevCategory() int {
+	if m.category == category.Search {
+		return m.search.categoryBeforeSearch
+	}
+
 	isAtFirstCategory := m.category == category.FrontPage
 	if isAtFirstCategory && m.favorites.HasItems() {
 		return category.Favorites
 	}
 
 	if isAtFirstCategory {
-		return category.Show
+		return category.Launch
 	}
 
 	return m.category - 1
@@ -467,8 +509,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		m.items[category.Favorites] = m.favorites.GetItems()
 
-		fetchCmd := m.FetchFrontPageStories()
-		cmds = append(cmds, fetchCmd)
+		if m.startupUser != "" {
+			cmds = append(cmds, m.openProfile(m.startupUser))
+		} else {
+			cmds = append(cmds, m.FetchFrontPageStories())
+		}
+
+		cmds = append(cmds, m.scheduleAutoRefresh())
 
 		heightOfHeaderAndStatusLine := 2
 
@@ -476,12 +523,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.viewport.YPosition = 2
 		m.viewport.HighPerformanceRendering = false
 
-		content := lipgloss.NewStyle().
-			Width(windowSizeMsg.Width).
-			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
-
-		m.viewport.SetContent(content.String())
+		m.setHelpScreenContent()
 
 		return m, tea.Batch(cmds...)
 	}
@@ -502,7 +544,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		h, v := lipgloss.NewStyle().GetFrameSize()
 		m.setSize(screen.GetTerminalWidth()-h, screen.GetTerminalHeight()-v)
 		m.disableInput = false
-		m.NewStatusMessage(msg.Message)
+		m.cancelFetch = nil
+		m.isOffline = msg.Offline
+
+		if msg.Err != nil {
+			return m, m.NewStatusMessageWithDuration(hn.ErrorMessage(msg.Err), time.Second*3)
+		}
 
 		return m, nil
 
@@ -513,10 +560,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.favorites.Add(msg.Item)
 		m.items[category.Favorites] = m.favorites.GetItems()
 
-		m.favorites.Write()
-
 		m.updatePagination()
 
+		if err := m.favorites.Write(); err != nil {
+			return m, m.NewStatusMessageWithDuration("Could not save favorites", time.Second*3)
+		}
+
 	case tea.WindowSizeMsg:
 		h, v := lipgloss.NewStyle().GetFrameSize()
 		m.SetSize(msg.Width-h, msg.Height-v)
@@ -531,34 +580,84 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		content := lipgloss.NewStyle().
-			Width(msg.Width).
-			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
-
-		m.viewport.SetContent(content.String())
+		m.setHelpScreenContent()
 
 		return m, nil
 
 	case message.EnteringCommentSection:
-		lastVisited := m.history.GetLastVisited(msg.Id)
+		ctx := m.newFetchContext()
+
+		return m, func() tea.Msg {
+			story, err := m.service.FetchComments(ctx, msg.Id)
+
+			return message.CommentsFetched{
+				Id:           msg.Id,
+				CommentCount: msg.CommentCount,
+				Story:        story,
+				Offline:      m.isServiceOffline(),
+				Err:          err,
+			}
+		}
 
-		m.history.MarkAsReadAndWriteToDisk(msg.Id, msg.CommentCount)
+	case message.CommentsFetched:
+		m.StopSpinner()
+		m.cancelFetch = nil
+
+		if msg.Err != nil {
+			m.SetDisabledInput(false)
 
-		story := m.service.FetchComments(msg.Id)
+			return m, m.NewStatusMessageWithDuration(hn.ErrorMessage(msg.Err), time.Second*3)
+		}
+
+		m.isOffline = msg.Offline
+
+		lastVisited := m.history.GetLastVisited(msg.Id)
+
+		m.history.MarkAsReadAndWriteToDisk(msg.Story, msg.CommentCount)
 
 		if m.category == category.Favorites {
-			m.favorites.UpdateStoryAndWriteToDisk(story)
+			// The comment section is shown regardless, the favorite is updated
+			// again on the next visit
+			_ = m.favorites.UpdateStoryAndWriteToDisk(msg.Story)
 		}
 
-		commentTree := tree.Print(story, m.config, m.width, lastVisited)
+		m.SetIsVisible(false)
+
+		commentTree := tree.Print(msg.Story, m.config, m.width, lastVisited)
 
-		command := cli.Less(commentTree, m.config)
+		command := cli.CommentSection(commentTree, m.config)
 
 		return m, tea.ExecProcess(command, func(err error) tea.Msg {
-			return message.EditorFinishedMsg{Err: err}
+			return getCommentSectionAction(msg.Id, err)
 		})
 
+	case message.CommentSectionAction:
+		return m, m.handleCommentSectionAction(msg)
+
+	case message.ReplyRequested:
+		return m, m.composeReply(msg.Id)
+
+	case message.ExportRequested:
+		m.SetIsVisible(true)
+		m.SetDisabledInput(false)
+
+		return m, m.exportThread(msg.Id)
+
+	case message.ExportFinished:
+		return m, m.NewStatusMessageWithDuration(getExportFinishedMessage(msg), time.Second*5)
+
+	case composerview.Edited:
+		return m, m.handleDraftEdited(msg)
+
+	case message.DraftPreviewed:
+		return m, m.showPostPrompt()
+
+	case composerview.Posted:
+		return m, m.handleDraftPosted(msg)
+
+	case message.ActionFinished:
+		return m, m.NewStatusMessageWithDuration(getActionFinishedMessage(msg), time.Second*3)
+
 	case message.EnteringReaderMode:
 		errorMessage := validator.GetErrorMessage(msg.Title, msg.Domain)
 		if errorMessage != "" {
@@ -571,7 +670,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		article, err := reader.GetArticle(msg.Url, msg.Title, m.config.CommentWidth, m.config.IndentationSymbol)
 		if err != nil {
-			panic(err)
+			cmds = append(cmds, m.NewStatusMessageWithDuration(getArticleErrorMessage(err), time.Second*3))
+			cmds = append(cmds, func() tea.Msg {
+				return message.EditorFinishedMsg{Err: nil}
+			})
+
+			return m, tea.Batch(cmds...)
 		}
 
 		command := cli.Less(article, m.config)
@@ -585,33 +689,102 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.SetDisabledInput(false)
 
 	case message.ChangeCategory:
+		ctx := m.newFetchContext()
+		if msg.Refresh {
+			ctx = hn.WithRefresh(ctx)
+		}
+
 		return m, func() tea.Msg {
 			itemsToFetch := m.getNumberOfItemsToFetch(msg.Category)
-			stories, errMsg := m.service.FetchItems(itemsToFetch, msg.Category)
-
-			m.items[msg.Category] = stories
+			stories, err := m.service.FetchItems(ctx, 0, itemsToFetch, msg.Category)
+			if err == nil {
+				m.items[msg.Category] = stories
+			}
 
-			return message.CategoryFetchingFinished{Category: msg.Category, Cursor: msg.Cursor, Message: errMsg}
+			return message.CategoryFetchingFinished{
+				Category: msg.Category,
+				Cursor:   msg.Cursor,
+				Offline:  m.isServiceOffline(),
+				Err:      err,
+			}
 		}
 
 	case message.CategoryFetchingFinished:
-		m.Paginator.Page = 0
 		m.SetDisabledInput(false)
 		m.StopSpinner()
+		m.cancelFetch = nil
+
+		if msg.Err != nil {
+			m.restoreCategoryAfterFailedFetch(msg.Category)
+
+			return m, m.NewStatusMessageWithDuration(hn.ErrorMessage(msg.Err), time.Second*3)
+		}
+
+		m.Paginator.Page = 0
 		m.category = msg.Category
+		m.isOffline = msg.Offline
+		m.isExhausted[msg.Category] = false
+		m.newStories[msg.Category] = 0
 
 		itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
 		m.cursor = min(msg.Cursor, itemsOnPage-1)
 
-		m.NewStatusMessage(msg.Message)
+		m.updatePagination()
+
+	case message.SearchFinished:
+		return m, m.handleSearchFinished(msg)
+
+	case message.AutoRefreshTick:
+		return m, m.autoRefresh()
+
+	case message.AutoRefreshFinished:
+		// Failed refreshes are retried silently on the next tick
+		if msg.Err == nil {
+			m.patchItems(msg.Items)
+			m.newStories[msg.Category] = msg.NewStories
+		}
+
+		return m, m.scheduleAutoRefresh()
+
+	case message.MoreItemsFetched:
+		m.isFetchingMore = false
+
+		if !m.disableInput {
+			m.StopSpinner()
+		}
+
+		if msg.Err != nil {
+			return m, m.NewStatusMessageWithDuration(hn.ErrorMessage(msg.Err), time.Second*3)
+		}
+
+		// The category has been refreshed while the batch was loading
+		if len(m.items[msg.Category]) != msg.Offset {
+			return m, nil
+		}
+
+		newItems := m.withoutLoadedItems(msg.Category, msg.Items)
+		if len(newItems) == 0 {
+			m.isExhausted[msg.Category] = true
+
+			return m, nil
+		}
+
+		m.items[msg.Category] = append(m.items[msg.Category], newItems...)
+		m.isOffline = msg.Offline
 
 		m.updatePagination()
+
+		return m, nil
 	}
 
 	if m.isOnHelpScreen {
 		return m.updateHelpScreen(msg)
 	}
 
+	if m.isOnSettingsScreen {
+		return m.updateSettingsScreen(msg)
+	}
+
 	cmds = append(cmds, m.handleBrowsing(msg))
 
 	return m, tea.Batch(cmds...)
@@ -645,12 +818,7 @@ func (m Model) updateHelpScreen(msg tea.Msg) (Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		content := lipgloss.NewStyle().
-			Width(msg.Width).
-			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
-
-		m.viewport.SetContent(content.String())
+		m.setHelpScreenContent()
 
 		return m, viewport.Sync(m.viewport)
 
@@ -663,6 +831,81 @@ func (m Model) updateHelpScreen(msg tea.Msg) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+func (m *Model) setHelpScreenContent() {
+	content := lipgloss.NewStyle().
+		Width(m.viewport.Width).
+		AlignHorizontal(lipgloss.Center).
+		SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
+
+	m.viewport.SetContent(content.String())
+}
+
+func (m *Model) restoreCategoryAfterFailedFetch(cat int) {
+	// A failed refresh leaves the old stories in the buffer category. Move them
+	// back so that the user keeps browsing what was on screen before.
+	if m.category == category.Buffer {
+		m.items[cat] = m.items[category.Buffer]
+		m.category = cat
+	}
+
+	m.categoryToDisplay = m.category
+	m.updatePagination()
+}
+
+// fetchMoreIfOnLastPage loads the next batch of the category in the
+// background once the last loaded page has been reached.
+func (m *Model) fetchMoreIfOnLastPage() tea.Cmd {
+	cat := m.category
+	isOnLastPage := m.Paginator.Page >= m.Paginator.TotalPages-1
+
+	if !isOnLastPage || m.isFetchingMore || m.isExhausted[cat] ||
+		cat == category.Favorites || cat == category.Buffer {
+		return nil
+	}
+
+	m.isFetchingMore = true
+
+	if cat == category.Search {
+		return tea.Batch(m.StartSpinner(), m.fetchSearchPage(context.Background(), m.search.page+1))
+	}
+
+	offset := len(m.items[cat])
+	itemsToFetch := m.getNumberOfItemsToFetch(cat)
+
+	fetchCmd := func() tea.Msg {
+		stories, err := m.service.FetchItems(context.Background(), offset, itemsToFetch, cat)
+
+		return message.MoreItemsFetched{
+			Category: cat,
+			Offset:   offset,
+			Items:    stories,
+			Offline:  m.isServiceOffline(),
+			Err:      err,
+		}
+	}
+
+	return tea.Batch(m.StartSpinner(), fetchCmd)
+}
+
+// withoutLoadedItems filters out stories that are already in the category.
+// Rankings shift between requests, so consecutive batches can overlap.
+func (m *Model) withoutLoadedItems(cat int, items []*item.Item) []*item.Item {
+	loaded := make(map[int]bool, len(m.items[cat]))
+	for _, it := range m.items[cat] {
+		loaded[it.ID] = true
+	}
+
+	var newItems []*item.Item
+
+	for _, it := range items {
+		if !loaded[it.ID] {
+			newItems = append(newItems, it)
+		}
+	}
+
+	return newItems
+}
+
 func (m *Model) updateCursor() {
 	m.cursor = min(m.cursor, m.Paginator.ItemsOnPage(len(m.VisibleItems()))-1)
 }
@@ -683,6 +926,10 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	numItems := len(m.VisibleItems())
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.search.isOnPrompt {
+		return m.handleSearchPrompt(keyMsg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
@@ -691,6 +938,11 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			return nil
 
+		case msg.String() == "S":
+			m.isOnSettingsScreen = true
+
+			return nil
+
 		case m.onAddToFavoritesPrompt && msg.String() == "y":
 			m.onAddToFavoritesPrompt = false
 			m.disableInput = false
@@ -708,11 +960,15 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.onRemoveFromFavoritesPrompt = false
 			m.disableInput = false
 
-			//
-			m.favorites.Remove(m.Index())
+			if err := m.favorites.Remove(m.Index()); err != nil {
+				return m.NewStatusMessageWithDuration("Could not remove item", time.Second*3)
+			}
+
 			m.items[category.Favorites] = m.favorites.GetItems()
 
-			m.favorites.Write()
+			if err := m.favorites.Write(); err != nil {
+				return m.NewStatusMessageWithDuration("Could not save favorites", time.Second*3)
+			}
 
 			//
 			isOnLastItem := m.Index() == len(m.items[category.Favorites])
@@ -739,19 +995,71 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			return m.NewStatusMessageWithDuration(itemRemovedMessage, time.Second*2)
 
-		case m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt:
+		case m.onPostPrompt && msg.String() == "y":
+			return m.postDraft()
+
+		case m.onPostPrompt && msg.String() == "e":
+			m.onPostPrompt = false
+			m.hideStatusMessage()
+
+			return m.composeReply(m.SelectedItem().ID)
+
+		case m.onPostPrompt:
+			return m.leaveComposer(composerview.KeptStatus(m.draft))
+
+		case m.onFlagPrompt && msg.String() == "y":
+			m.onFlagPrompt = false
+			m.disableInput = false
+
+			m.hideStatusMessage()
+
+			return m.performAction(m.SelectedItem().ID, session.Flag)
+
+		case m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt || m.onFlagPrompt:
 			m.onAddToFavoritesPrompt = false
 			m.onRemoveFromFavoritesPrompt = false
+			m.onFlagPrompt = false
 			m.disableInput = false
 
 			m.hideStatusMessage()
 
 			return nil
 
+		case msg.String() == "ctrl+c":
+			m.cancelInFlightFetch()
+
+			return tea.Quit
+
+		case m.disableInput && msg.String() == "esc" && m.cancelFetch != nil:
+			m.cancelInFlightFetch()
+
+			return nil
+
 		case m.disableInput:
 			return nil
 
-		case msg.String() == "q" || msg.String() == "esc" || msg.String() == "ctrl+c":
+		case msg.String() == "/":
+			m.hideStatusMessage()
+			m.search.isOnPrompt = true
+
+			return nil
+
+		case msg.String() == "esc" && m.category == category.Search:
+			return m.leaveSearch()
+
+		case msg.String() == "r" && m.category == category.Search && m.search.user != nil:
+			return m.openProfile(m.search.user.Name)
+
+		case msg.String() == "r" && m.category == category.Search:
+			return m.startSearch()
+
+		case msg.String() == "p" && m.category == category.Search && m.search.user != nil:
+			return m.showProfile()
+
+		case msg.String() == "u" && m.SelectedItem().User != "":
+			return m.openProfile(m.SelectedItem().User)
+
+		case msg.String() == "q" || msg.String() == "esc":
 			return tea.Quit
 
 		case msg.String() == "up" || msg.String() == "k":
@@ -774,7 +1082,7 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.Paginator.NextPage()
 			m.updateCursor()
 
-			return nil
+			return m.fetchMoreIfOnLastPage()
 
 		case msg.String() == "tab":
 			nextCat := m.getNextCategory()
@@ -860,17 +1168,18 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.cursor = min(m.cursor, len(m.items[m.category])-1)
 			m.updatePagination()
 
-			m.items[category.FrontPage] = []*item.Item{}
-			m.items[category.New] = []*item.Item{}
-			m.items[category.Ask] = []*item.Item{}
-			m.items[category.Show] = []*item.Item{}
+			for cat := category.FrontPage; cat < category.Favorites; cat++ {
+				m.items[cat] = []*item.Item{}
+				m.isExhausted[cat] = false
+				m.newStories[cat] = 0
+			}
 
 			m.SetDisabledInput(true)
 			m.cursor = 0
 			m.Paginator.Page = currentPage
 
 			changeCatCmd := func() tea.Msg {
-				return message.ChangeCategory{Category: currentCategory, Cursor: m.cursor}
+				return message.ChangeCategory{Category: currentCategory, Cursor: m.cursor, Refresh: true}
 			}
 
 			cmds = append(cmds, m.StartSpinner())
@@ -892,8 +1201,22 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			return nil
 
+		case msg.String() == "U":
+			return m.performAction(m.SelectedItem().ID, session.Upvote)
+
+		case msg.String() == "F":
+			return m.performAction(m.SelectedItem().ID, session.Favorite)
+
+		case msg.String() == "X":
+			return m.showFlagPrompt()
+
+		case msg.String() == "R":
+			return m.composeReply(m.SelectedItem().ID)
+
+		case msg.String() == "E":
+			return m.exportThread(m.SelectedItem().ID)
+
 		case msg.String() == "enter":
-			m.SetIsVisible(false)
 			m.SetDisabledInput(true)
 
 			cmd := func() tea.Msg {
@@ -903,7 +1226,7 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 				}
 			}
 
-			return cmd
+			return tea.Batch(m.StartSpinner(), cmd)
 
 		case msg.String() == " ":
 			m.SetIsVisible(false)
@@ -949,6 +1272,12 @@ func (m Model) View() string {
 			m.statusAndPaginationView())
 	}
 
+	if m.isOnSettingsScreen {
+		return fmt.Sprintf("%s\n%s\n%s", header.GetHeader(m.categoryToDisplay, m.favorites.HasItems(), m.width),
+			m.settingsView(m.height-2),
+			m.statusAndPaginationView())
+	}
+
 	var (
 		sections    []string
 		availHeight = m.height
@@ -985,6 +1314,16 @@ func (m Model) View() string {
 }
 
 func (m Model) titleView() string {
+	if m.categoryToDisplay == category.Search && m.search.user != nil {
+		u := m.search.user
+
+		return header.GetUserHeader(u.Name, u.Karma, u.JoinedAgo(), m.width) + "\n"
+	}
+
+	if m.categoryToDisplay == category.Search {
+		return header.GetSearchHeader(m.search.options.Query, m.width) + "\n"
+	}
+
 	return header.GetHeader(m.categoryToDisplay, m.favorites.HasItems(), m.width) + "\n"
 }
 
@@ -995,14 +1334,22 @@ func (m Model) statusAndPaginationView() string {
 	if m.isOnHelpScreen {
 		centerContent = lipgloss.NewStyle().Faint(true).Render(
 			"github.com/bensadeh/circumflex • version " + app.Version)
+	} else if m.search.isOnPrompt {
+		centerContent = m.searchPromptView()
 	} else if m.showSpinner {
 		centerContent = m.spinnerView()
+	} else if m.statusMessage == "" && m.isOffline {
+		centerContent = lipgloss.NewStyle().Faint(true).Render("offline • showing cached stories")
+	} else if m.statusMessage == "" && m.newStories[m.category] > 0 {
+		centerContent = m.newStoriesView()
 	} else {
 		centerContent = m.statusMessage
 	}
 
 	if m.isOnHelpScreen {
 		rightContent = "hlp"
+	} else if m.isOnSettingsScreen {
+		rightContent = "set"
 	} else {
 		rightContent = m.Paginator.View()
 	}
@@ -1053,6 +1400,12 @@ func (m *Model) SetDisabledInput(value bool) {
 	m.disableInput = value
 }
 
+// SetStartupUser opens the profile of the user instead of the front page on
+// startup.
+func (m *Model) SetStartupUser(name string) {
+	m.startupUser = name
+}
+
 func (m *Model) SetOnStartup(value bool) {
 	m.onStartup = value
 }
@@ -1098,6 +1451,16 @@ func (m Model) spinnerView() string {
 	return m.spinner.View()
 }
 
+// getArticleErrorMessage points out articles that are missing from the
+// offline snapshot.
+func getArticleErrorMessage(err error) string {
+	if errors.Is(err, hn.ErrNotSynced) {
+		return hn.ErrorMessage(err)
+	}
+
+	return "Could not fetch article"
+}
+
 func getAddItemConfirmationMessage() string {
 	normal := lipgloss.NewStyle().
 		Foreground(style.GetUnselectedItemFg()).
@@ -1,6 +1,10 @@
 package message
 
-import "clx/item"
+import (
+	"clx/item"
+	"clx/session"
+	"clx/user"
+)
 
 type EditorFinishedMsg struct {
 	Err error
@@ -19,19 +23,81 @@ type EnteringReaderMode struct {
 
 type StatusMessageTimeout struct{}
 
+type CommentsFetched struct {
+	Id           int
+	CommentCount int
+	Story        *item.Item
+	Offline      bool
+	Err          error
+}
+
 type FetchingFinished struct {
-	Message string
+	Offline bool
+	Err     error
 }
 
 type ChangeCategory struct {
 	Category int
 	Cursor   int
+	Refresh  bool
 }
 
 type CategoryFetchingFinished struct {
 	Category int
 	Cursor   int
-	Message  string
+	Offline  bool
+	Err      error
+}
+
+type MoreItemsFetched struct {
+	Category int
+	Offset   int
+	Items    []*item.Item
+	Offline  bool
+	Err      error
+}
+
+type SearchFinished struct {
+	Generation int
+	Page       int
+	Items      []*item.Item
+	NbPages    int
+	User       *user.User
+	Err        error
+}
+
+type AutoRefreshTick struct{}
+
+type AutoRefreshFinished struct {
+	Category   int
+	Items      []*item.Item
+	NewStories int
+	Err        error
+}
+
+type CommentSectionAction struct {
+	Id     int
+	Action session.Action
+}
+
+type ReplyRequested struct {
+	Id int
+}
+
+type ExportRequested struct {
+	Id int
+}
+
+type ExportFinished struct {
+	Path string
+	Err  error
+}
+
+type DraftPreviewed struct{}
+
+type ActionFinished struct {
+	Action session.Action
+	Err    error
 }
 
 type AddToFavorites struct {
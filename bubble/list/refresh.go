@@ -0,0 +1,157 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"clx/bubble/list/message"
+	"clx/constants/category"
+	"clx/hn/services"
+	"clx/hn/services/firebase"
+	"clx/item"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	minAutoRefreshInterval = 10 * time.Second
+	autoRefreshTimeout     = 30 * time.Second
+)
+
+// scheduleAutoRefresh returns the next tick of the auto-refresh, or nil if it
+// is disabled. Ticks are only scheduled once the previous refresh has
+// finished so that slow responses don't pile up.
+func (m *Model) scheduleAutoRefresh() tea.Cmd {
+	if m.config.AutoRefresh <= 0 || !services.UsesNetwork(m.config) {
+		return nil
+	}
+
+	interval := m.config.AutoRefresh
+	if interval < minAutoRefreshInterval {
+		interval = minAutoRefreshInterval
+	}
+
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return message.AutoRefreshTick{}
+	})
+}
+
+// autoRefresh fetches the items that have changed since the last tick and
+// counts the stories that have entered the ranking of the current category.
+// Updates always come from Firebase, which is the only API with a feed of
+// changes.
+func (m *Model) autoRefresh() tea.Cmd {
+	cat := m.category
+	loaded := m.loadedIDs()
+	shown := getIDs(m.items[cat])
+	service := &firebase.Service{BaseURL: m.config.FirebaseURL}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), autoRefreshTimeout)
+		defer cancel()
+
+		updated, err := service.FetchUpdates(ctx)
+		if err != nil {
+			return message.AutoRefreshFinished{Category: cat, Err: err}
+		}
+
+		var changed []int
+
+		for _, id := range updated {
+			if loaded[id] {
+				changed = append(changed, id)
+			}
+		}
+
+		items, err := service.FetchItemsByID(ctx, changed)
+		if err != nil {
+			return message.AutoRefreshFinished{Category: cat, Err: err}
+		}
+
+		return message.AutoRefreshFinished{
+			Category:   cat,
+			Items:      items,
+			NewStories: countNewStories(ctx, service, cat, shown),
+		}
+	}
+}
+
+func countNewStories(ctx context.Context, service *firebase.Service, cat int, shown []int) int {
+	isRanked := cat < category.Favorites
+	if !isRanked || len(shown) == 0 {
+		return 0
+	}
+
+	// Categories without a list on Firebase can't be checked
+	ranking, err := service.FetchStoryIDs(ctx, cat)
+	if err != nil {
+		return 0
+	}
+
+	isShown := make(map[int]bool, len(shown))
+	for _, id := range shown {
+		isShown[id] = true
+	}
+
+	newStories := 0
+
+	for _, id := range firebase.Page(ranking, 0, len(shown)) {
+		if !isShown[id] {
+			newStories++
+		}
+	}
+
+	return newStories
+}
+
+// patchItems updates points and comment counts of the loaded items in place,
+// which keeps the cursor and page where they are.
+func (m *Model) patchItems(updated []*item.Item) {
+	byID := make(map[int]*item.Item, len(updated))
+	for _, it := range updated {
+		byID[it.ID] = it
+	}
+
+	for _, items := range m.items {
+		for _, it := range items {
+			if u, ok := byID[it.ID]; ok {
+				it.Points = u.Points
+				it.CommentsCount = u.CommentsCount
+			}
+		}
+	}
+}
+
+func (m *Model) loadedIDs() map[int]bool {
+	loaded := make(map[int]bool)
+
+	for _, items := range m.items {
+		for _, it := range items {
+			loaded[it.ID] = true
+		}
+	}
+
+	return loaded
+}
+
+func getIDs(items []*item.Item) []int {
+	ids := make([]int, 0, len(items))
+
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+
+	return ids
+}
+
+func (m Model) newStoriesView() string {
+	stories := "stories"
+	if m.newStories[m.category] == 1 {
+		stories = "story"
+	}
+
+	return lipgloss.NewStyle().Faint(true).Render(
+		fmt.Sprintf("%d new %s • press r to refresh", m.newStories[m.category], stories))
+}
@@ -0,0 +1,282 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"clx/bubble/list/message"
+	"clx/cli"
+	"clx/constants/category"
+	"clx/hn"
+	"clx/hn/services/algolia"
+	"clx/meta"
+	"clx/user"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var searchDateRanges = []struct {
+	name   string
+	period time.Duration
+}{
+	{name: "all time", period: 0},
+	{name: "last 24h", period: 24 * time.Hour},
+	{name: "past week", period: 7 * 24 * time.Hour},
+	{name: "past month", period: 30 * 24 * time.Hour},
+	{name: "past year", period: 365 * 24 * time.Hour},
+}
+
+type search struct {
+	isOnPrompt bool
+	query      string
+	scope      algolia.Scope
+	sortByDate bool
+	dateRange  int
+
+	// The remaining fields describe the results that are currently shown.
+	// User is set if they are the submissions and comments of a user.
+	options              algolia.SearchOptions
+	user                 *user.User
+	hitsPerPage          int
+	page                 int
+	generation           int
+	categoryBeforeSearch int
+}
+
+func (s *search) getOptions(now time.Time) algolia.SearchOptions {
+	options := algolia.SearchOptions{
+		Query:      strings.TrimSpace(s.query),
+		Scope:      s.scope,
+		SortByDate: s.sortByDate,
+	}
+
+	if period := searchDateRanges[s.dateRange].period; period != 0 {
+		options.Since = now.Add(-period)
+	}
+
+	return options
+}
+
+func (m *Model) handleSearchPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.cancelInFlightFetch()
+
+		return tea.Quit
+
+	case tea.KeyEsc:
+		m.search.isOnPrompt = false
+
+	case tea.KeyEnter:
+		if strings.TrimSpace(m.search.query) == "" {
+			return nil
+		}
+
+		return m.startSearch()
+
+	case tea.KeyBackspace:
+		if runes := []rune(m.search.query); len(runes) > 0 {
+			m.search.query = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeyCtrlU:
+		m.search.query = ""
+
+	case tea.KeyTab:
+		m.search.scope = (m.search.scope + 1) % (algolia.ScopeComments + 1)
+
+	case tea.KeyCtrlO:
+		m.search.sortByDate = !m.search.sortByDate
+
+	case tea.KeyCtrlT:
+		m.search.dateRange = (m.search.dateRange + 1) % len(searchDateRanges)
+
+	case tea.KeySpace, tea.KeyRunes:
+		m.search.query += string(msg.Runes)
+	}
+
+	return nil
+}
+
+func (m *Model) startSearch() tea.Cmd {
+	m.search.isOnPrompt = false
+	m.search.generation++
+	m.search.options = m.search.getOptions(time.Now())
+	m.search.hitsPerPage = m.Paginator.PerPage * 3
+	m.search.page = 0
+
+	m.SetDisabledInput(true)
+
+	return tea.Batch(m.StartSpinner(), m.fetchSearchPage(m.newFetchContext(), 0))
+}
+
+// openProfile shows the profile of a user together with their most recent
+// submissions and comments.
+func (m *Model) openProfile(name string) tea.Cmd {
+	if !user.IsValidName(name) {
+		return m.NewStatusMessageWithDuration("Not a valid username: "+name, time.Second*3)
+	}
+
+	m.search.generation++
+	m.search.options = algolia.SearchOptions{Author: name, Scope: algolia.ScopeAll, SortByDate: true}
+	m.search.hitsPerPage = m.Paginator.PerPage * 3
+	m.search.page = 0
+
+	m.SetDisabledInput(true)
+
+	ctx := m.newFetchContext()
+	fetchFirstPage := m.fetchSearchPage(ctx, 0)
+	generation := m.search.generation
+
+	return tea.Batch(m.StartSpinner(), func() tea.Msg {
+		u, err := m.service.FetchUser(ctx, name)
+		if err != nil {
+			return message.SearchFinished{Generation: generation, Err: err}
+		}
+
+		msg, _ := fetchFirstPage().(message.SearchFinished)
+		msg.User = u
+
+		return msg
+	})
+}
+
+func (m *Model) showProfile() tea.Cmd {
+	m.SetIsVisible(false)
+	m.SetDisabledInput(true)
+
+	command := cli.Less(meta.GetUserProfileMetaBlock(m.search.user, m.config), m.config)
+
+	return tea.ExecProcess(command, func(err error) tea.Msg {
+		return message.EditorFinishedMsg{Err: err}
+	})
+}
+
+func (m *Model) fetchSearchPage(ctx context.Context, page int) tea.Cmd {
+	service := &algolia.Service{BaseURL: m.config.AlgoliaURL}
+	options := m.search.options
+	hitsPerPage := m.search.hitsPerPage
+	generation := m.search.generation
+
+	return func() tea.Msg {
+		result, err := service.Search(ctx, options, page, hitsPerPage)
+		if err != nil {
+			return message.SearchFinished{Generation: generation, Page: page, Err: err}
+		}
+
+		return message.SearchFinished{
+			Generation: generation,
+			Page:       page,
+			Items:      result.Items,
+			NbPages:    result.NbPages,
+		}
+	}
+}
+
+func (m *Model) handleSearchFinished(msg message.SearchFinished) tea.Cmd {
+	// The results belong to a search that has since been replaced
+	if msg.Generation != m.search.generation {
+		return nil
+	}
+
+	if msg.Page == 0 {
+		m.SetDisabledInput(false)
+		m.StopSpinner()
+		m.cancelFetch = nil
+	} else {
+		m.isFetchingMore = false
+
+		if !m.disableInput {
+			m.StopSpinner()
+		}
+	}
+
+	if msg.Err != nil {
+		return tea.Batch(m.NewStatusMessageWithDuration(hn.ErrorMessage(msg.Err), time.Second*3),
+			m.showCategoryIfEmpty())
+	}
+
+	m.search.page = msg.Page
+	m.isExhausted[category.Search] = msg.Page+1 >= msg.NbPages
+
+	if msg.Page > 0 {
+		m.items[category.Search] = append(m.items[category.Search], msg.Items...)
+		m.updatePagination()
+
+		return nil
+	}
+
+	if len(msg.Items) == 0 {
+		noResults := fmt.Sprintf("No results for '%s'", m.search.options.Query)
+		if msg.User != nil {
+			noResults = fmt.Sprintf("%s has no submissions or comments", msg.User.Name)
+		}
+
+		return tea.Batch(m.NewStatusMessageWithDuration(noResults, time.Second*3), m.showCategoryIfEmpty())
+	}
+
+	if m.category != category.Search {
+		m.search.categoryBeforeSearch = m.category
+	}
+
+	m.search.user = msg.User
+	m.items[category.Search] = msg.Items
+	m.changeToCategory(category.Search)
+	m.cursor = 0
+
+	if msg.User != nil {
+		return m.NewStatusMessageWithDuration("Press p to read the full profile", time.Second*3)
+	}
+
+	return nil
+}
+
+func (m *Model) leaveSearch() tea.Cmd {
+	return m.showCategory(m.search.categoryBeforeSearch)
+}
+
+// showCategoryIfEmpty fetches the current category if a search was started
+// before it had any stories, e.g. when going directly to a profile.
+func (m *Model) showCategoryIfEmpty() tea.Cmd {
+	if m.categoryHasStories(m.category) {
+		return nil
+	}
+
+	return m.showCategory(m.category)
+}
+
+func (m *Model) showCategory(cat int) tea.Cmd {
+	if m.categoryHasStories(cat) {
+		m.changeToCategory(cat)
+
+		return nil
+	}
+
+	m.SetDisabledInput(true)
+	m.categoryToDisplay = cat
+
+	changeCatCmd := func() tea.Msg {
+		return message.ChangeCategory{Category: cat, Cursor: m.cursor}
+	}
+
+	return tea.Batch(m.StartSpinner(), changeCatCmd)
+}
+
+func (m Model) searchPromptView() string {
+	scope := "stories"
+	if m.search.scope == algolia.ScopeComments {
+		scope = "comments"
+	}
+
+	sort := "relevance"
+	if m.search.sortByDate {
+		sort = "date"
+	}
+
+	options := fmt.Sprintf("   tab %s • ^o %s • ^t %s", scope, sort, searchDateRanges[m.search.dateRange].name)
+
+	return "/" + m.search.query + "▏" + lipgloss.NewStyle().Faint(true).Render(options)
+}
@@ -0,0 +1,175 @@
+package list
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/constants/style"
+	"clx/file"
+	"clx/history"
+	"clx/settings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	commentWidthStep = 5
+	minCommentWidth  = 40
+	maxCommentWidth  = 200
+)
+
+type editableSetting struct {
+	key   string
+	label string
+}
+
+// The settings on the settings screen are read from the config on every
+// render, so changes take effect immediately
+var editableSettings = []editableSetting{
+	{key: "COMMENT_WIDTH", label: "Comment width"},
+	{key: "AUTO_EXPAND", label: "Expand all replies"},
+	{key: "DISABLE_EMOJIS", label: "Disable emojis"},
+	{key: "NERDFONTS", label: "Nerd Fonts"},
+	{key: "PLAIN_HEADLINES", label: "Plain headlines"},
+	{key: "PLAIN_COMMENTS", label: "Plain comments"},
+	{key: "HIDE_INDENT", label: "Hide indentation bar"},
+	{key: "DISABLE_HISTORY", label: "Disable history"},
+}
+
+func (m Model) updateSettingsScreen(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc", "S":
+			m.isOnSettingsScreen = false
+			m.hideStatusMessage()
+
+			return m, nil
+
+		case "j", "down":
+			m.settingsCursor = min(m.settingsCursor+1, len(editableSettings)-1)
+
+		case "k", "up":
+			m.settingsCursor = max(m.settingsCursor-1, 0)
+
+		case "enter", " ":
+			return m, m.toggleSetting(editableSettings[m.settingsCursor].key)
+
+		case "h", "left":
+			return m, m.changeCommentWidth(editableSettings[m.settingsCursor].key, -commentWidthStep)
+
+		case "l", "right":
+			return m, m.changeCommentWidth(editableSettings[m.settingsCursor].key, commentWidthStep)
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := lipgloss.NewStyle().GetFrameSize()
+		m.SetSize(msg.Width-h, msg.Height-v)
+
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	return m, nil
+}
+
+func (m *Model) toggleSetting(key string) tea.Cmd {
+	value, _ := m.config.Get(key)
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil
+	}
+
+	return m.saveSetting(key, strconv.FormatBool(!b))
+}
+
+func (m *Model) changeCommentWidth(key string, step int) tea.Cmd {
+	if key != "COMMENT_WIDTH" {
+		return nil
+	}
+
+	width := min(max(m.config.CommentWidth+step, minCommentWidth), maxCommentWidth)
+
+	return m.saveSetting(key, strconv.Itoa(width))
+}
+
+// saveSetting applies the setting to the running program and writes it to
+// the config file.
+func (m *Model) saveSetting(key string, value string) tea.Cmd {
+	if err := m.config.Set(key, value); err != nil {
+		return m.NewStatusMessageWithDuration(err.Error(), time.Second*3)
+	}
+
+	switch key {
+	case "HIDE_INDENT":
+		m.config.IndentationSymbol = settings.IndentationSymbol(getConfiguredIndentationSymbol(),
+			m.config.HideIndentSymbol)
+
+	case "DISABLE_HISTORY":
+		m.history = history.New(m.config.DebugMode, m.config.DoNotMarkSubmissionsAsRead)
+
+	case "NERDFONTS":
+		m.setHelpScreenContent()
+	}
+
+	if err := settings.Save(file.PathToConfigFile(), key, value); err != nil {
+		return m.NewStatusMessageWithDuration("Could not save settings", time.Second*3)
+	}
+
+	return m.NewStatusMessageWithDuration("Saved to "+file.ConfigFileNameFull, time.Second*2)
+}
+
+// getConfiguredIndentationSymbol reads the symbol from the config file and the
+// environment, since the one in use has been replaced while the indentation
+// was hidden.
+func getConfiguredIndentationSymbol() string {
+	config, err := settings.Load(file.PathToConfigFile(), os.Environ())
+	if err != nil {
+		return settings.Default().IndentationSymbol
+	}
+
+	return config.IndentationSymbol
+}
+
+func (m Model) settingsView(height int) string {
+	label := lipgloss.NewStyle().Width(24)
+	selected := lipgloss.NewStyle().Foreground(style.GetBlue()).Bold(true)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	var sb strings.Builder
+
+	for i, s := range editableSettings {
+		value, _ := m.config.Get(s.key)
+
+		switch value {
+		case "true":
+			value = "on"
+		case "false":
+			value = faint.Render("off")
+		}
+
+		if s.key == "COMMENT_WIDTH" {
+			value = "‹ " + value + " ›"
+		}
+
+		line := label.Render(s.label) + value
+
+		if i == m.settingsCursor {
+			line = selected.Render("▸ ") + selected.Render(label.Render(s.label)) + value
+		} else {
+			line = "  " + line
+		}
+
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("\n" + faint.Render("j/k select • enter toggle • h/l change width • q back"))
+
+	box := lipgloss.NewStyle().Align(lipgloss.Left).Render(sb.String())
+
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, box)
+}
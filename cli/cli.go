@@ -12,11 +12,21 @@ import (
 )
 
 func Less(input string, config *settings.Config) *exec.Cmd {
+	return less(input, config, config.LesskeyPath)
+}
+
+// CommentSection is like Less, but keys for actions on Hacker News quit less
+// with the key as the exit status.
+func CommentSection(input string, config *settings.Config) *exec.Cmd {
+	return less(input, config, config.CommentSectionLesskeyPath)
+}
+
+func less(input string, config *settings.Config, lesskeyPath string) *exec.Cmd {
 	args := []string{
 		"--RAW-CONTROL-CHARS",
 		"--pattern=" + unicode.ZeroWidthSpace,
 		"--ignore-case",
-		"--lesskey-src=" + config.LesskeyPath,
+		"--lesskey-src=" + lesskeyPath,
 		"--tilde",
 		"--use-color",
 		"-P?e" + "\u001B[48;5;232m " + "\u001B[38;5;200m" + "E" + "\u001B[38;5;214m" + "n" + "\u001B[38;5;69m" + "d " + "\033[0m",
@@ -38,6 +48,22 @@ func Less(input string, config *settings.Config) *exec.Cmd {
 	return command
 }
 
+// Editor returns the command that opens the file in $VISUAL or $EDITOR,
+// falling back to vi.
+func Editor(path string) *exec.Cmd {
+	editor := []string{"vi"}
+
+	for _, variable := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(variable)); len(fields) > 0 {
+			editor = fields
+
+			break
+		}
+	}
+
+	return exec.Command(editor[0], append(editor[1:], path)...)
+}
+
 func ClearScreen() {
 	c := exec.Command("clear")
 	c.Stdout = os.Stdout
@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"strconv"
+	"context"
+	"os"
 
-	"clx/favorites"
-	"clx/hn/services/hybrid"
+	"clx/hn"
 
 	"github.com/spf13/cobra"
 )
@@ -13,21 +13,28 @@ func addCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:                   "add",
 		Short:                 "Add item to list of favorites by ID",
-		Long:                  "Add item to list of favorites by ID",
+		Long:                  "Add item to list of favorites by ID or URL. Comments are added as the story they belong to.",
 		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completeItemIDs,
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			id, err := strconv.Atoi(args[0])
+			id := parseID(args[0])
+
+			service := getService(getConfig())
+
+			// Comments are added as the story they were posted to
+			submission, _, err := hn.FindStory(context.Background(), service, id)
 			if err != nil {
-				panic("ID format error")
+				println("Could not fetch item: " + hn.ErrorMessage(err))
+				os.Exit(1)
 			}
 
-			service := hybrid.Service{}
-			submission := service.FetchItem(id)
-
-			fav := favorites.New()
+			fav := loadFavorites()
 			fav.Add(submission)
-			fav.Write()
+
+			if err := fav.Write(); err != nil {
+				exitWithError(err)
+			}
 
 			println("Item added to favorites")
 		},
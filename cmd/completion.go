@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"clx/favorites"
+	"clx/file"
+	"clx/history"
+
+	"github.com/spf13/cobra"
+)
+
+// maxRecentStories is the number of stories from the history that are
+// suggested in addition to the favorites
+const maxRecentStories = 30
+
+func completionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish]",
+		Short: "Generate the completion script for bash, zsh or fish",
+		Long: "Generate the completion script for bash, zsh or fish. IDs are completed with the titles of " +
+			"favorites and recently visited stories.\n\n" +
+			"bash:  clx completion bash > /etc/bash_completion.d/clx\n" +
+			"zsh:   clx completion zsh > \"${fpath[1]}/_clx\"\n" +
+			"fish:  clx completion fish > ~/.config/fish/completions/clx.fish",
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+
+			switch args[0] {
+			case "bash":
+				err = cmd.Root().GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				err = cmd.Root().GenZshCompletion(os.Stdout)
+			case "fish":
+				err = cmd.Root().GenFishCompletion(os.Stdout, true)
+			}
+
+			if err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+}
+
+// completeItemIDs suggests the IDs of favorites followed by the most recently
+// visited stories, with their titles as descriptions.
+func completeItemIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return filterCompletions(append(getFavoriteCompletions(), getHistoryCompletions()...), args, toComplete),
+		cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFavoriteIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(getFavoriteCompletions(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFavoriteToMove only completes the first argument since the second
+// one is a position.
+func completeFavoriteToMove(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective,
+) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completeFavoriteIDs(cmd, args, toComplete)
+}
+
+func completeHistoryIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(getHistoryCompletions(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func getFavoriteCompletions() []string {
+	fav, err := favorites.New()
+	if err != nil {
+		return nil
+	}
+
+	completions := make([]string, 0, len(fav.GetItems()))
+
+	for _, it := range fav.GetItems() {
+		completions = append(completions, completion(it.ID, it.Title))
+	}
+
+	return completions
+}
+
+func getHistoryCompletions() []string {
+	// Reading the history creates it if it is missing, which is not expected
+	// from pressing tab
+	if !file.Exists(history.PathToHistoryFile()) {
+		return nil
+	}
+
+	stories := history.NewPersistentHistory().GetVisitedStories()
+
+	ids := make([]int, 0, len(stories))
+	for id := range stories {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return stories[ids[i]].LastVisited > stories[ids[j]].LastVisited
+	})
+
+	completions := make([]string, 0, min(len(ids), maxRecentStories))
+
+	for _, id := range ids[0:min(len(ids), maxRecentStories)] {
+		completions = append(completions, completion(id, stories[id].Title))
+	}
+
+	return completions
+}
+
+func completion(id int, title string) string {
+	if title == "" {
+		return strconv.Itoa(id)
+	}
+
+	return strconv.Itoa(id) + "\t" + strings.ReplaceAll(title, "\t", " ")
+}
+
+// filterCompletions removes duplicates, IDs that have already been given as
+// arguments and IDs that do not start with what has been typed so far.
+func filterCompletions(completions []string, args []string, toComplete string) []string {
+	seen := make(map[string]bool)
+
+	for _, arg := range args {
+		seen[arg] = true
+	}
+	filtered := make([]string, 0, len(completions))
+
+	for _, c := range completions {
+		id, _, _ := strings.Cut(c, "\t")
+
+		if seen[id] || !strings.HasPrefix(id, toComplete) {
+			continue
+		}
+
+		seen[id] = true
+
+		filtered = append(filtered, c)
+	}
+
+	return filtered
+}
+
+func fixedCompletions(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+}
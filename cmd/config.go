@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"clx/cli"
+	"clx/file"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+)
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Create, show, edit and validate the config file",
+		Long: "Create, show, edit and validate the config file at ~/.config/circumflex/config.env. Settings are " +
+			"read from the defaults, then the config file, then environment variables prefixed with " +
+			settings.EnvironmentPrefix + " (e.g. " + settings.EnvironmentPrefix + "COMMENT_WIDTH=80) and finally " +
+			"the flags, where each level overrides the previous one.",
+	}
+
+	cmd.AddCommand(configInitCmd())
+	cmd.AddCommand(configShowCmd())
+	cmd.AddCommand(configEditCmd())
+	cmd.AddCommand(configValidateCmd())
+
+	return cmd
+}
+
+func configInitCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a config file listing every setting with its default",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			configPath := file.PathToConfigFile()
+
+			if file.Exists(configPath) && !force {
+				exitWithError(fmt.Errorf("%s already exists, use --force to overwrite it", configPath))
+			}
+
+			if err := file.WriteToFile(configPath, getConfigTemplate()); err != nil {
+				exitWithError(err)
+			}
+
+			fmt.Println("Created " + configPath)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing config file")
+
+	return cmd
+}
+
+func configShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the settings in effect after applying the config file, environment and flags",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig()
+
+			for _, option := range settings.Options() {
+				value, _ := config.Get(option.Key)
+
+				fmt.Printf("%s=%s\n", option.Key, settings.Quote(value))
+			}
+		},
+	}
+}
+
+func configEditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in $EDITOR and validate it afterwards",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			configPath := file.PathToConfigFile()
+
+			if !file.Exists(configPath) {
+				if err := file.WriteToFile(configPath, getConfigTemplate()); err != nil {
+					exitWithError(err)
+				}
+			}
+
+			editor := cli.Editor(configPath)
+			editor.Stdin = os.Stdin
+			editor.Stdout = os.Stdout
+			editor.Stderr = os.Stderr
+
+			if err := editor.Run(); err != nil {
+				exitWithError(fmt.Errorf("could not open the editor: %w", err))
+			}
+
+			validateConfig(configPath)
+		},
+	}
+}
+
+func configValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file and the environment for unknown keys and invalid values",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			validateConfig(file.PathToConfigFile())
+		},
+	}
+}
+
+func validateConfig(configPath string) {
+	_, err := settings.Load(configPath, os.Environ())
+
+	loadErr := new(settings.LoadError)
+	if err != nil && !errors.As(err, &loadErr) {
+		exitWithError(err)
+	}
+
+	// Other commands skip unknown environment variables, but they are most
+	// likely typos when validating
+	for _, name := range settings.UnknownVariables(os.Environ()) {
+		loadErr.Problems = append(loadErr.Problems, fmt.Sprintf("environment variable %s: unknown key '%s'",
+			name, strings.TrimPrefix(name, settings.EnvironmentPrefix)))
+	}
+
+	switch {
+	case len(loadErr.Problems) > 0:
+		fmt.Println(loadErr)
+		os.Exit(1)
+
+	case !file.Exists(configPath):
+		fmt.Printf("%s does not exist, run 'clx config init' to create it\n", configPath)
+
+	default:
+		fmt.Printf("%s is valid\n", configPath)
+	}
+}
+
+// getConfigTemplate lists every setting, commented out and set to its
+// default, together with the usage of the corresponding flag.
+func getConfigTemplate() string {
+	var sb strings.Builder
+
+	sb.WriteString("# circumflex config file\n#\n")
+	sb.WriteString("# Remove the # in front of a setting to change it. Environment variables prefixed with " +
+		settings.EnvironmentPrefix + "\n")
+	sb.WriteString("# and flags override the settings in this file.\n")
+
+	defaults := settings.Default()
+
+	for _, option := range settings.Options() {
+		description := option.Description
+
+		if option.Flag != "" {
+			flag := rootFlags.Lookup(option.Flag)
+			if flag.Hidden {
+				continue
+			}
+
+			description = flag.Usage
+		}
+
+		value, _ := defaults.Get(option.Key)
+
+		sb.WriteString(fmt.Sprintf("\n# %s\n# %s=%s\n", description, option.Key, settings.Quote(value)))
+	}
+
+	return sb.String()
+}
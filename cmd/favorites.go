@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"clx/favorites"
+	"clx/hn"
+	"clx/item"
+
+	"github.com/spf13/cobra"
+)
+
+func favoritesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "favorites",
+		Short: "Manage the list of favorites",
+		Long: "List, remove, reorder, export and import favorites. Favorites are stored in " +
+			"~/.config/circumflex/favorites.json.",
+	}
+
+	cmd.AddCommand(favoritesListCmd())
+	cmd.AddCommand(favoritesRemoveCmd())
+	cmd.AddCommand(favoritesMoveCmd())
+	cmd.AddCommand(favoritesExportCmd())
+	cmd.AddCommand(favoritesImportCmd())
+
+	return cmd
+}
+
+func favoritesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Print the favorites with their position, ID, title and URL",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			clean := strings.NewReplacer("\t", " ", "\n", " ")
+
+			for i, it := range loadFavorites().GetItems() {
+				fmt.Printf("%d\t%d\t%s\t%s\n", i+1, it.ID, clean.Replace(it.Title), it.URL)
+			}
+		},
+	}
+}
+
+func favoritesRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove [ID]...",
+		Short:             "Remove items from the favorites by ID",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFavoriteIDs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fav := loadFavorites()
+
+			for _, arg := range args {
+				index := fav.Index(parseID(arg))
+				if index == -1 {
+					exitWithError(fmt.Errorf("%s is not a favorite", arg))
+				}
+
+				if err := fav.Remove(index); err != nil {
+					exitWithError(err)
+				}
+			}
+
+			if err := fav.Write(); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+}
+
+func favoritesMoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "move [ID] [position]",
+		Short:             "Move a favorite to another position",
+		Long:              "Move a favorite to another position, where 1 is the top of the list",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFavoriteToMove,
+		Run: func(cmd *cobra.Command, args []string) {
+			fav := loadFavorites()
+
+			from := fav.Index(parseID(args[0]))
+			if from == -1 {
+				exitWithError(fmt.Errorf("%s is not a favorite", args[0]))
+			}
+
+			to, err := strconv.Atoi(args[1])
+			if err != nil {
+				exitWithError(fmt.Errorf("position must be a number: %s", args[1]))
+			}
+
+			if err := fav.Move(from, to-1); err != nil {
+				exitWithError(err)
+			}
+
+			if err := fav.Write(); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+}
+
+func favoritesExportCmd() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the favorites as JSON, CSV, bookmark HTML or OPML",
+		Long: "Export the favorites as JSON, CSV, Netscape bookmark HTML or OPML. Bookmarks and OPML outlines link " +
+			"to the discussion on Hacker News so that they can be imported again. Without --format, the format " +
+			"is taken from the extension of --output.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if output != "" && !cmd.Flags().Changed("format") {
+				format = strings.TrimPrefix(filepath.Ext(output), ".")
+			}
+
+			if !contains(favorites.Formats(), format) {
+				exitWithError(fmt.Errorf("unknown format '%s', expected one of: %s", format,
+					strings.Join(favorites.Formats(), ", ")))
+			}
+
+			items := loadFavorites().GetItems()
+
+			if output == "" {
+				if err := favorites.Export(os.Stdout, items, format); err != nil {
+					exitWithError(err)
+				}
+
+				return
+			}
+
+			if err := exportFavoritesToFile(output, items, format); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", favorites.FormatJSON, "set the format: '"+
+		strings.Join(favorites.Formats(), "', '")+"'")
+	cmd.Flags().StringVar(&output, "output", "", "write to a file instead of stdout")
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedCompletions(favorites.Formats()...))
+
+	return cmd
+}
+
+// exportFavoritesToFile removes the file again if the export fails so that no
+// truncated export is left behind.
+func exportFavoritesToFile(output string, items []*item.Item, format string) error {
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", output, err)
+	}
+
+	if err := favorites.Export(file, items, format); err != nil {
+		_ = file.Close()
+		_ = os.Remove(output)
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(output)
+
+		return fmt.Errorf("could not write %s: %w", output, err)
+	}
+
+	return nil
+}
+
+func favoritesImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import favorites from bookmark HTML or a list of IDs and URLs",
+		Long: "Import favorites from Netscape bookmark HTML or from a list with one item ID, Hacker News URL or " +
+			"Algolia URL per line. Use - to read from stdin. Each item is fetched from Hacker News, comments are " +
+			"imported as the story they belong to and items that are already favorites are skipped.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ids, unrecognized, err := parseFavoritesToImport(args[0])
+			if err != nil {
+				exitWithError(err)
+			}
+
+			for _, entry := range unrecognized {
+				fmt.Fprintln(os.Stderr, "Skipped, not a Hacker News item: "+entry)
+			}
+
+			fav := loadFavorites()
+			service := getService(getConfig())
+			imported := 0
+
+			for _, id := range ids {
+				if fav.Index(id) != -1 {
+					continue
+				}
+
+				it, _, err := hn.FindStory(context.Background(), service, id)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Could not fetch item %d: %s\n", id, hn.ErrorMessage(err))
+
+					continue
+				}
+
+				// Comments are imported as the story they were posted to
+				if fav.Index(it.ID) != -1 {
+					continue
+				}
+
+				fav.Add(it)
+				imported++
+			}
+
+			if err := fav.Write(); err != nil {
+				exitWithError(err)
+			}
+
+			fmt.Printf("Imported %d of %d items\n", imported, len(ids)+len(unrecognized))
+		},
+	}
+}
+
+func loadFavorites() *favorites.Favorites {
+	fav, err := favorites.New()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	return fav
+}
+
+func parseID(arg string) int {
+	id, err := hn.ParseID(arg)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	return id
+}
+
+// parseFavoritesToImport reads the IDs from the file, or from stdin if path
+// is -.
+func parseFavoritesToImport(path string) ([]int, []string, error) {
+	if path == "-" {
+		return favorites.ParseIDs(os.Stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open %s: %w", path, err)
+	}
+
+	ids, unrecognized, err := favorites.ParseIDs(file)
+
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("could not close %s: %w", path, closeErr)
+	}
+
+	return ids, unrecognized, err
+}
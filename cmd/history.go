@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/history"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyTimeFormat = "2006-01-02 15:04"
+	maxBarWidth       = 40
+	topEntries        = 10
+)
+
+func historyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List, prune and summarize the history of visited stories",
+		Long: "List, prune and summarize the history of visited stories in " +
+			"~/.cache/circumflex/history.json. Use 'clx clear' to remove the whole history.",
+	}
+
+	cmd.AddCommand(historyListCmd())
+	cmd.AddCommand(historyPruneCmd())
+	cmd.AddCommand(historyForgetCmd())
+	cmd.AddCommand(historyStatsCmd())
+
+	return cmd
+}
+
+func historyListCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print the visited stories, most recent first",
+		Long: "Print the visited stories, most recent first, with the time of the last visit, the ID, the number " +
+			"of visits and the title",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if limit < 0 {
+				exitWithError(fmt.Errorf("limit must be at least 0, got %d", limit))
+			}
+
+			stories := history.NewPersistentHistory().GetVisitedStories()
+
+			ids := make([]int, 0, len(stories))
+			for id := range stories {
+				ids = append(ids, id)
+			}
+
+			sort.Slice(ids, func(i, j int) bool {
+				return stories[ids[i]].LastVisited > stories[ids[j]].LastVisited
+			})
+
+			if limit > 0 {
+				ids = ids[0:min(limit, len(ids))]
+			}
+
+			clean := strings.NewReplacer("\t", " ", "\n", " ")
+
+			for _, id := range ids {
+				info := stories[id]
+
+				fmt.Printf("%s\t%d\t%d\t%s\n", time.Unix(info.LastVisited, 0).Format(historyTimeFormat), id,
+					max(1, len(info.Visits)), clean.Replace(info.Title))
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "set the maximum number of stories, or 0 for all")
+
+	return cmd
+}
+
+func historyPruneCmd() *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove stories that have not been visited for a while",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if olderThan == "" {
+				exitWithError(fmt.Errorf("--older-than is required, e.g. --older-than 30d"))
+			}
+
+			before, err := parseDate(olderThan, time.Now())
+			if err != nil {
+				exitWithError(err)
+			}
+
+			pruned := history.NewPersistentHistory().PruneAndWriteToDisk(before)
+
+			fmt.Printf("Removed %d stories last visited before %s\n", pruned, before.Format(historyTimeFormat))
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "remove stories last visited before a date "+
+		"(2006-01-02) or a period ago (24h, 30d)")
+
+	return cmd
+}
+
+func historyForgetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "forget [ID]...",
+		Short:             "Remove stories from the history so that they are shown as unread",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeHistoryIDs,
+		Run: func(cmd *cobra.Command, args []string) {
+			his := history.NewPersistentHistory()
+
+			for _, arg := range args {
+				if !his.ForgetAndWriteToDisk(parseID(arg)) {
+					fmt.Println(arg + " is not in the history")
+				}
+			}
+		},
+	}
+}
+
+func historyStatsCmd() *cobra.Command {
+	var days int
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show visits per day, the most read domains and the threads that were revisited",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if days < 1 {
+				exitWithError(fmt.Errorf("--days must be at least 1"))
+			}
+
+			stats := history.NewStats(history.NewPersistentHistory().GetVisitedStories(), time.Now(), days)
+
+			fmt.Printf("%d stories read, %d visits\n", stats.Stories, stats.Visits)
+
+			printVisitsPerDay(stats.VisitsPerDay)
+
+			fmt.Println("\nMost read domains")
+
+			for _, d := range stats.Domains[0:min(topEntries, len(stats.Domains))] {
+				fmt.Printf("%5d  %s\n", d.Stories, d.Domain)
+			}
+
+			fmt.Println("\nThreads revisited")
+
+			for _, r := range stats.Revisited[0:min(topEntries, len(stats.Revisited))] {
+				title := r.Title
+				if title == "" {
+					title = strconv.Itoa(r.ID)
+				}
+
+				fmt.Printf("%5d  %s\n", r.Visits, title)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 14, "set the number of days to show visits for")
+
+	return cmd
+}
+
+func printVisitsPerDay(visitsPerDay []history.DayCount) {
+	fmt.Println("\nVisits per day")
+
+	mostVisits := 0
+	for _, d := range visitsPerDay {
+		mostVisits = max(mostVisits, d.Visits)
+	}
+
+	for _, d := range visitsPerDay {
+		bar := 0
+		if mostVisits > 0 {
+			bar = d.Visits * maxBarWidth / mostVisits
+		}
+
+		fmt.Printf("%s  %s %d\n", d.Day.Format("2006-01-02"), strings.Repeat("█", bar), d.Visits)
+	}
+}
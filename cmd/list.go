@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/constants/category"
+	"clx/favorites"
+	"clx/history"
+	"clx/hn"
+	"clx/item"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+)
+
+type listItem struct {
+	Rank        int       `json:"rank"`
+	ID          int       `json:"id"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url,omitempty"`
+	Domain      string    `json:"domain,omitempty"`
+	Author      string    `json:"author"`
+	Points      int       `json:"points"`
+	Comments    int       `json:"comments"`
+	Time        time.Time `json:"time"`
+	Read        bool      `json:"read"`
+	NewComments int       `json:"new_comments"`
+}
+
+func listCmd() *cobra.Command {
+	var (
+		limit int
+		out   output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [category]",
+		Short: "Print the stories of a category",
+		Long: "Print the stories of a category (" + strings.Join(category.Names(), ", ") + ") without " +
+			"starting the main view. Stories that have been read are marked using the history, together with the " +
+			"number of comments that have been posted since.",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: category.Names(),
+		Run: func(cmd *cobra.Command, args []string) {
+			if limit < 1 {
+				exitWithError(fmt.Errorf("limit must be at least 1, got %d", limit))
+			}
+
+			name := "top"
+			if len(args) == 1 {
+				name = args[0]
+			}
+
+			cat, ok := category.FromName(name)
+			if !ok {
+				exitWithError(fmt.Errorf("unknown category '%s', expected one of: %s", name,
+					strings.Join(category.Names(), ", ")))
+			}
+
+			write, err := getWriter(cmd, out, listColumns)
+			if err != nil {
+				exitWithError(err)
+			}
+
+			config := getConfig()
+
+			items, err := fetchCategory(config, cat, limit)
+			if err != nil {
+				println("Could not fetch stories: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
+
+			if err := write(os.Stdout, annotate(items, history.New(config.DebugMode, config.DoNotMarkSubmissionsAsRead))); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 30, "set the maximum number of stories")
+	out.addFlags(cmd, formatTSV, "{{.Rank}}. {{.Title}}")
+
+	return cmd
+}
+
+func fetchCategory(config *settings.Config, cat int, limit int) ([]*item.Item, error) {
+	if cat == category.Favorites {
+		fav, err := favorites.New()
+		if err != nil {
+			return nil, err
+		}
+
+		items := fav.GetItems()
+
+		return items[0:min(limit, len(items))], nil
+	}
+
+	service := getService(config)
+
+	items, err := service.FetchItems(context.Background(), 0, limit, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	return items[0:min(limit, len(items))], nil
+}
+
+func annotate(items []*item.Item, his history.History) []listItem {
+	annotated := make([]listItem, 0, len(items))
+
+	for i, it := range items {
+		li := listItem{
+			Rank:     i + 1,
+			ID:       it.ID,
+			Type:     it.Type,
+			Title:    it.Title,
+			URL:      it.URL,
+			Domain:   it.Domain,
+			Author:   it.User,
+			Points:   it.Points,
+			Comments: it.CommentsCount,
+			Time:     time.Unix(it.Time, 0),
+			Read:     his.Contains(it.ID),
+		}
+
+		if li.Read {
+			li.NewComments = max(0, it.CommentsCount-his.GetLastCommentCount(it.ID))
+		}
+
+		annotated = append(annotated, li)
+	}
+
+	return annotated
+}
+
+var listColumns = []column[listItem]{
+	{name: "rank", value: func(it listItem) string { return strconv.Itoa(it.Rank) }},
+	{name: "id", value: func(it listItem) string { return strconv.Itoa(it.ID) }},
+	{name: "points", value: func(it listItem) string { return strconv.Itoa(it.Points) }},
+	{name: "comments", value: func(it listItem) string { return strconv.Itoa(it.Comments) }},
+	{name: "read", value: func(it listItem) string { return strconv.FormatBool(it.Read) }},
+	{name: "new", value: func(it listItem) string { return strconv.Itoa(it.NewComments) }},
+	{name: "title", value: func(it listItem) string { return it.Title }},
+	{name: "url", value: func(it listItem) string { return it.URL }},
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"clx/hn"
+	"clx/session"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func loginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login [username]",
+		Short: "Log in to Hacker News",
+		Long: "Log in to Hacker News to upvote, favorite and flag submissions from within circumflex. The " +
+			"login cookie is kept in ~/.config/circumflex/session and is only readable by the current user.",
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig()
+			configureHTTPClient(config)
+
+			username := ""
+			if len(args) == 1 {
+				username = args[0]
+			} else {
+				fmt.Print("Username: ")
+
+				line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil {
+					exitWithError(fmt.Errorf("could not read username: %w", err))
+				}
+
+				username = strings.TrimSpace(line)
+			}
+
+			fmt.Print("Password: ")
+
+			password, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+			fmt.Println()
+
+			if err != nil {
+				exitWithError(fmt.Errorf("could not read password: %w", err))
+			}
+
+			s := &session.Session{BaseURL: config.HackerNewsURL}
+
+			err = s.Login(context.Background(), username, string(password))
+			if errors.Is(err, session.ErrBadLogin) {
+				println("Bad login, check the username and password")
+				os.Exit(1)
+			}
+
+			if err != nil {
+				println("Could not log in: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
+
+			println("Logged in as " + username)
+		},
+	}
+}
+
+func logoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:                   "logout",
+		Short:                 "Log out of Hacker News",
+		Long:                  "Log out of Hacker News by removing the login cookie from ~/.config/circumflex/session.",
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			s := &session.Session{}
+
+			if err := s.Logout(); err != nil {
+				exitWithError(err)
+			}
+
+			println("Logged out")
+		},
+	}
+}
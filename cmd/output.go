@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatJSONL    = "jsonl"
+	formatTSV      = "tsv"
+	formatTemplate = "template"
+)
+
+var outputFormats = []string{formatTable, formatJSON, formatJSONL, formatTSV, formatTemplate}
+
+// column is printed by the table and TSV formats
+type column[T any] struct {
+	name  string
+	value func(row T) string
+}
+
+// output holds the --format and --template flags that the scripting commands
+// share.
+type output struct {
+	format   string
+	template string
+}
+
+func (o *output) addFlags(cmd *cobra.Command, defaultFormat string, example string) {
+	cmd.Flags().StringVar(&o.format, "format", defaultFormat, "set the output format: '"+
+		strings.Join(outputFormats, "', '")+"'")
+	cmd.Flags().StringVar(&o.template, "template", "", "print each row with a Go template, e.g. '"+example+"'")
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedCompletions(outputFormats...))
+}
+
+// getWriter returns the function that prints the rows in the selected format.
+// --template implies the template format unless --format has been set.
+func getWriter[T any](cmd *cobra.Command, o output, columns []column[T]) (func(w io.Writer, rows []T) error, error) {
+	format := o.format
+	if o.template != "" && !cmd.Flags().Changed("format") {
+		format = formatTemplate
+	}
+
+	switch format {
+	case formatTable:
+		return func(w io.Writer, rows []T) error {
+			return writeTable(w, rows, columns)
+		}, nil
+
+	case formatJSON:
+		return writeJSON[T], nil
+
+	case formatJSONL:
+		return writeJSONL[T], nil
+
+	case formatTSV:
+		return func(w io.Writer, rows []T) error {
+			return writeTSV(w, rows, columns)
+		}, nil
+
+	case formatTemplate:
+		return getTemplateWriter[T](o.template)
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(outputFormats, ", "))
+	}
+}
+
+func getTemplateWriter[T any](tmpl string) (func(w io.Writer, rows []T) error, error) {
+	if tmpl == "" {
+		return nil, fmt.Errorf("the '%s' format requires --template", formatTemplate)
+	}
+
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return func(w io.Writer, rows []T) error {
+		for _, row := range rows {
+			if err := t.Execute(w, row); err != nil {
+				return fmt.Errorf("could not execute template: %w", err)
+			}
+
+			fmt.Fprintln(w)
+		}
+
+		return nil
+	}, nil
+}
+
+// cleanColumn replaces tabs and line breaks to keep the columns intact
+var cleanColumn = strings.NewReplacer("\t", " ", "\n", " ")
+
+func writeTable[T any](w io.Writer, rows []T, columns []column[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, strings.ToUpper(c.name))
+	}
+
+	fmt.Fprintln(tw, strings.Join(names, "\t"))
+
+	if err := writeTSV(tw, rows, columns); err != nil {
+		return err
+	}
+
+	return tw.Flush()
+}
+
+// writeTSV prints one row per line without a header so that the output can be
+// piped into cut, awk and the like.
+func writeTSV[T any](w io.Writer, rows []T, columns []column[T]) error {
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			values = append(values, cleanColumn.Replace(c.value(row)))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+			return fmt.Errorf("could not write output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeJSON[T any](w io.Writer, rows []T) error {
+	if rows == nil {
+		rows = []T{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(rows); err != nil {
+		return fmt.Errorf("could not encode output: %w", err)
+	}
+
+	return nil
+}
+
+func writeJSONL[T any](w io.Writer, rows []T) error {
+	encoder := json.NewEncoder(w)
+
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return fmt.Errorf("could not encode output: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -1,53 +1,101 @@
 package cmd
 
 import (
+	"context"
 	_ "embed"
+	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
+	"strings"
 
 	"clx/less"
 	"clx/reader"
 
-	"clx/hn/services/hybrid"
+	"clx/hn"
 
 	"clx/cli"
 	"github.com/spf13/cobra"
 )
 
 func readCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:                   "read",
-		Short:                 "Read the linked article associated with an item based on the ID",
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "read",
+		Short: "Read the linked article associated with an item based on the ID",
+		Long: "Read the linked article associated with an item based on the ID or URL. With --output, the article is " +
+			"saved as Markdown, HTML or EPUB depending on the file extension instead.",
 		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completeItemIDs,
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			id, convErr := strconv.Atoi(args[0])
-			if convErr != nil {
-				println("Argument must be a valid ID")
-				os.Exit(1)
+			id := parseID(args[0])
+
+			format := strings.TrimPrefix(filepath.Ext(output), ".")
+
+			if output != "" && !contains(reader.Formats(), format) {
+				exitWithError(fmt.Errorf("unknown file extension '%s', expected one of: %s", filepath.Ext(output),
+					strings.Join(reader.Formats(), ", ")))
 			}
 
-			service := new(hybrid.Service)
+			config := getConfig()
+
+			service := getService(config)
 
-			item := service.FetchItem(id)
+			// Comments are read as the article of the story they belong to
+			item, _, err := hn.FindStory(context.Background(), service, id)
+			if err != nil {
+				println("Could not fetch item: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
 
 			if item.URL == "" {
-				println("Could not find any links associated with the ID " + args[0])
+				println("Could not find any links associated with " + args[0])
 				os.Exit(1)
 			}
 
-			config := getConfig()
+			if output != "" {
+				if err := exportArticle(output, item.URL, item.Title, format); err != nil {
+					println("Could not export article: " + hn.ErrorMessage(err))
+					os.Exit(1)
+				}
+
+				return
+			}
 
 			article, _ := reader.GetArticle(item.URL, item.Title, config.CommentWidth, config.IndentationSymbol)
 
 			lesskey := less.NewLesskey()
+			config.LesskeyPath = lesskey.GetPath()
+			defer lesskey.Remove()
 
 			command := cli.Less(article, config)
 
 			if err := command.Run(); err != nil {
-				defer lesskey.Remove()
 				panic(err)
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&output, "output", "", "save the article to a file ending in "+
+		"."+strings.Join(reader.Formats(), ", .")+" instead of reading it")
+	_ = cmd.MarkFlagFilename("output", reader.Formats()...)
+
+	return cmd
+}
+
+func exportArticle(path string, url string, title string, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", path, err)
+	}
+
+	if err := reader.Export(file, url, title, format); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return err
+	}
+
+	return file.Close()
 }
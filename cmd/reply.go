@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"clx/bubble"
+	"clx/composer"
+
+	"github.com/spf13/cobra"
+)
+
+func replyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reply",
+		Short: "Reply to a story or comment by ID",
+		Long: "Write a reply in $EDITOR, preview it and post it to Hacker News. Markdown-style emphasis, links, " +
+			"lists and code blocks are converted to the Hacker News format. The draft is kept in " +
+			"~/.cache/circumflex/drafts until it has been posted.",
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completeItemIDs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			id := parseID(args[0])
+
+			config := getConfig()
+			setIndentationSymbol(config)
+			configureHTTPClient(config)
+
+			bubble.RunComposer(config, composer.NewReply(id))
+		},
+	}
+}
@@ -3,17 +3,26 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"clx/app"
 	"clx/bubble"
 	"clx/cli"
-	"clx/indent"
+	"clx/file"
+	"clx/hn"
+	"clx/hn/services"
+	"clx/hn/services/hybrid"
 	"clx/less"
+	"clx/offline"
+	"clx/reader"
 	"clx/settings"
+	"clx/utils/http"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/logrusorgru/aurora/v3"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -29,6 +38,25 @@ var (
 	forceDarkMode               bool
 	autoExpandComments          bool
 	noLessVerify                bool
+	commentSource               string
+	backend                     string
+	firebaseURL                 string
+	algoliaURL                  string
+	hackerWebURL                string
+	hackerNewsURL               string
+	replayDirectory             string
+	recordResponses             bool
+	disableCache                bool
+	timeout                     time.Duration
+	userAgent                   string
+	proxy                       string
+	maxConcurrentRequests       int
+	requestsPerSecond           int
+	autoRefresh                 time.Duration
+	offlineMode                 bool
+	offlineDirectory            string
+
+	rootFlags *pflag.FlagSet
 )
 
 func Root() *cobra.Command {
@@ -38,24 +66,40 @@ func Root() *cobra.Command {
 		Version: app.Version,
 		Run: func(cmd *cobra.Command, args []string) {
 			config := getConfig()
-			config.IndentationSymbol = indent.GetIndentSymbol(hideIndentSymbol)
 
-			verifyLess(noLessVerify)
+			setIndentationSymbol(config)
+
+			verifyLess(config.NoLessVerify)
 
-			lesskey := less.NewLesskey()
+			lesskey := less.NewLesskeyWithCommentSection()
 			config.LesskeyPath = lesskey.GetPath()
+			config.CommentSectionLesskeyPath = lesskey.GetCommentSectionPath()
 			defer lesskey.Remove()
 
-			bubble.Run(config)
+			service := getService(config)
+
+			bubble.Run(config, service)
 		},
 	}
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	rootCmd.AddCommand(addCmd())
+	rootCmd.AddCommand(favoritesCmd())
 	rootCmd.AddCommand(clearCmd())
+	rootCmd.AddCommand(completionCmd())
+	rootCmd.AddCommand(configCmd())
+	rootCmd.AddCommand(historyCmd())
 	rootCmd.AddCommand(viewCmd())
 	rootCmd.AddCommand(readCmd())
+	rootCmd.AddCommand(listCmd())
+	rootCmd.AddCommand(searchCmd())
+	rootCmd.AddCommand(userCmd())
+	rootCmd.AddCommand(loginCmd())
+	rootCmd.AddCommand(logoutCmd())
+	rootCmd.AddCommand(replyCmd())
+	rootCmd.AddCommand(submitCmd())
+	rootCmd.AddCommand(syncCmd())
 	rootCmd.AddCommand(versionCmd())
 
 	configureFlags(rootCmd)
@@ -64,6 +108,8 @@ func Root() *cobra.Command {
 }
 
 func configureFlags(rootCmd *cobra.Command) {
+	rootFlags = rootCmd.PersistentFlags()
+
 	rootCmd.PersistentFlags().BoolVarP(&disableHeadlineHighlighting, "plain-headlines", "p", false,
 		"disable syntax highlighting for headlines")
 	rootCmd.PersistentFlags().BoolVarP(&disableCommentHighlighting, "plain-comments", "o", false,
@@ -86,25 +132,70 @@ func configureFlags(rootCmd *cobra.Command) {
 		"automatically expand all replies upon entering the comment section")
 	rootCmd.PersistentFlags().BoolVar(&noLessVerify, "no-less-verify", false,
 		"disable checking less version on startup")
+	rootCmd.PersistentFlags().StringVar(&commentSource, "comment-source", settings.Default().CommentSource,
+		"fetch comments from 'hackerweb' or directly from 'firebase'")
+	rootCmd.PersistentFlags().StringVar(&backend, "backend", settings.Default().Backend,
+		"set the backend to use: "+strings.Join(services.Names(), ", "))
+	rootCmd.PersistentFlags().StringVar(&firebaseURL, "firebase-url", settings.Default().FirebaseURL,
+		"set the base URL of the Firebase API")
+	rootCmd.PersistentFlags().StringVar(&algoliaURL, "algolia-url", settings.Default().AlgoliaURL,
+		"set the base URL of the Algolia API")
+	rootCmd.PersistentFlags().StringVar(&hackerWebURL, "hackerweb-url", settings.Default().HackerWebURL,
+		"set the base URL of the hackerweb API")
+	rootCmd.PersistentFlags().StringVar(&hackerNewsURL, "hn-url", settings.Default().HackerNewsURL,
+		"set the base URL of the Hacker News website used for logging in, voting, favoriting and flagging")
+	rootCmd.PersistentFlags().StringVar(&replayDirectory, "replay-dir", settings.Default().ReplayDirectory,
+		"set the directory used by the replay backend")
+	rootCmd.PersistentFlags().BoolVar(&disableCache, "disable-cache", false,
+		"disable caching responses on disk")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", settings.Default().Timeout,
+		"set the timeout for network requests")
+	rootCmd.PersistentFlags().StringVar(&userAgent, "user-agent", settings.Default().UserAgent,
+		"set the User-Agent header for network requests")
+	rootCmd.PersistentFlags().StringVar(&proxy, "proxy", "",
+		"send network requests through an HTTP(S) proxy")
+	rootCmd.PersistentFlags().IntVar(&maxConcurrentRequests, "max-concurrent-requests",
+		settings.Default().MaxConcurrentRequests, "set the maximum number of network requests in flight")
+	rootCmd.PersistentFlags().IntVar(&requestsPerSecond, "requests-per-second", 0,
+		"limit the number of network requests per second (0 for no limit)")
+
+	rootCmd.PersistentFlags().DurationVar(&autoRefresh, "auto-refresh", 0,
+		"update points and comment counts and check for new stories at an interval, e.g. 1m (0 to disable)")
+
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false,
+		"browse the stories, comments and articles downloaded with 'clx sync' without a connection")
+	rootCmd.PersistentFlags().StringVar(&offlineDirectory, "offline-dir", settings.Default().OfflineDirectory,
+		"set the directory that 'clx sync' downloads to")
 
 	rootCmd.PersistentFlags().BoolVarP(&debugMode, "debug-mode", "q", false,
 		"enable debug mode (offline mode) by using mock data for the endpoints")
 	rootCmd.Flag("debug-mode").Hidden = true
+
+	rootCmd.PersistentFlags().BoolVar(&recordResponses, "record", false,
+		"record responses from the backend to the replay directory")
+	rootCmd.Flag("record").Hidden = true
+
+	_ = rootCmd.RegisterFlagCompletionFunc("comment-source",
+		fixedCompletions(hybrid.CommentSourceHackerWeb, hybrid.CommentSourceFirebase))
+	_ = rootCmd.RegisterFlagCompletionFunc("backend", fixedCompletions(services.Names()...))
+	_ = rootCmd.MarkPersistentFlagDirname("replay-dir")
 }
 
 func getConfig() *settings.Config {
-	config := settings.Default()
-
-	config.CommentWidth = commentWidth
-	config.DisableHeadlineHighlighting = disableHeadlineHighlighting
-	config.DisableCommentHighlighting = disableCommentHighlighting
-	config.DoNotMarkSubmissionsAsRead = disableHistory
-	config.EnableNerdFonts = enableNerdFont
-	config.HideIndentSymbol = hideIndentSymbol
-	config.AutoExpandComments = autoExpandComments
-	config.DisableEmojis = disableEmojis
-	config.DebugMode = debugMode
-	config.NoLessVerify = noLessVerify
+	config, err := settings.Load(file.PathToConfigFile(), os.Environ())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%s\n", err)
+		os.Exit(1)
+	}
+
+	applyFlags(config)
+
+	if config.CommentSource != hybrid.CommentSourceHackerWeb && config.CommentSource != hybrid.CommentSourceFirebase {
+		fmt.Fprintf(os.Stderr, "Unknown comment source '%s', expected '%s' or '%s'\n", config.CommentSource,
+			hybrid.CommentSourceHackerWeb, hybrid.CommentSourceFirebase)
+
+		os.Exit(1)
+	}
 
 	if forceLightMode {
 		lipgloss.SetHasDarkBackground(false)
@@ -117,6 +208,61 @@ func getConfig() *settings.Config {
 	return config
 }
 
+// applyFlags overrides the config with the flags that were set on the command
+// line, which take precedence over the config file and the environment.
+func applyFlags(config *settings.Config) {
+	for _, option := range settings.Options() {
+		if option.Flag == "" {
+			continue
+		}
+
+		flag := rootFlags.Lookup(option.Flag)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+
+		// The value has already been parsed by the flag, so it is valid
+		_ = config.Set(option.Key, flag.Value.String())
+	}
+}
+
+// setIndentationSymbol picks the symbol that works in the current terminal
+// unless another one has been configured.
+func setIndentationSymbol(config *settings.Config) {
+	config.IndentationSymbol = settings.IndentationSymbol(config.IndentationSymbol, config.HideIndentSymbol)
+}
+
+func getService(config *settings.Config) hn.Service {
+	configureHTTPClient(config)
+
+	if config.Offline && !config.DebugMode {
+		if !offline.Exists(config.OfflineDirectory) {
+			exitWithError(fmt.Errorf("no snapshot in %s, run 'clx sync' to download one",
+				config.OfflineDirectory))
+		}
+
+		reader.UseSavedArticles(config.OfflineDirectory)
+	}
+
+	service, err := services.New(config)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	return service
+}
+
+func configureHTTPClient(config *settings.Config) {
+	options := http.DefaultOptions()
+	options.Timeout = config.Timeout
+	options.UserAgent = config.UserAgent
+	options.Proxy = config.Proxy
+	options.MaxConcurrentRequests = config.MaxConcurrentRequests
+	options.RequestsPerSecond = config.RequestsPerSecond
+
+	http.Configure(options)
+}
+
 func verifyLess(noLessVerify bool) {
 	if noLessVerify {
 		return
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/hn"
+	"clx/hn/services/algolia"
+
+	"github.com/spf13/cobra"
+)
+
+const maxHitsPerPage = 100
+
+var searchScopes = map[string]algolia.Scope{
+	"story":   algolia.ScopeStories,
+	"comment": algolia.ScopeComments,
+}
+
+type searchHit struct {
+	ID       int       `json:"id"`
+	StoryID  int       `json:"story_id"`
+	Type     string    `json:"type"`
+	Title    string    `json:"title"`
+	URL      string    `json:"url,omitempty"`
+	Domain   string    `json:"domain,omitempty"`
+	Author   string    `json:"author"`
+	Points   int       `json:"points"`
+	Comments int       `json:"comments"`
+	Time     time.Time `json:"time"`
+	Text     string    `json:"text,omitempty"`
+}
+
+func searchCmd() *cobra.Command {
+	var (
+		author      string
+		minPoints   int
+		minComments int
+		since       string
+		until       string
+		domain      string
+		itemType    string
+		sortByDate  bool
+		limit       int
+		out         output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search stories and comments on Algolia",
+		Long: "Search stories and comments on Algolia and print the results as a table, as JSON, as " +
+			"tab-separated values or through a Go template",
+		Run: func(cmd *cobra.Command, args []string) {
+			if limit < 1 {
+				exitWithError(fmt.Errorf("limit must be at least 1, got %d", limit))
+			}
+
+			now := time.Now()
+
+			sinceTime, err := parseDate(since, now)
+			if err != nil {
+				exitWithError(err)
+			}
+
+			untilTime, err := parseDate(until, now)
+			if err != nil {
+				exitWithError(err)
+			}
+
+			scope, ok := searchScopes[itemType]
+			if !ok {
+				exitWithError(fmt.Errorf("unknown type '%s', expected 'story' or 'comment'", itemType))
+			}
+
+			write, err := getWriter(cmd, out, searchColumns)
+			if err != nil {
+				exitWithError(err)
+			}
+
+			options := algolia.SearchOptions{
+				Query:       strings.Join(args, " "),
+				Scope:       scope,
+				SortByDate:  sortByDate,
+				Since:       sinceTime,
+				Until:       untilTime,
+				Author:      author,
+				MinPoints:   minPoints,
+				MinComments: minComments,
+				Domain:      domain,
+			}
+
+			config := getConfig()
+			configureHTTPClient(config)
+
+			service := &algolia.Service{BaseURL: config.AlgoliaURL}
+
+			hits, err := search(context.Background(), service, options, limit)
+			if err != nil {
+				println("Could not search: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
+
+			if err := write(os.Stdout, hits); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&author, "author", "", "only show items by this user")
+	cmd.Flags().IntVar(&minPoints, "min-points", 0, "only show stories with at least this many points")
+	cmd.Flags().IntVar(&minComments, "min-comments", 0, "only show stories with at least this many comments")
+	cmd.Flags().StringVar(&since, "since", "", "only show items posted after a date (2006-01-02) or "+
+		"a period ago (24h, 7d)")
+	cmd.Flags().StringVar(&until, "until", "", "only show items posted before a date (2006-01-02) or "+
+		"a period ago (24h, 7d)")
+	cmd.Flags().StringVar(&domain, "domain", "", "only show items linking to this domain")
+	cmd.Flags().StringVar(&itemType, "type", "story", "search 'story' or 'comment'")
+	cmd.Flags().BoolVar(&sortByDate, "by-date", false, "sort by date instead of relevance")
+	cmd.Flags().IntVar(&limit, "limit", 30, "set the maximum number of results")
+	out.addFlags(cmd, formatTable, "{{.ID}} {{.Title}}")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", fixedCompletions("story", "comment"))
+
+	return cmd
+}
+
+// search pages through the results until limit hits have been collected or
+// Algolia runs out of pages.
+func search(ctx context.Context, service *algolia.Service, options algolia.SearchOptions,
+	limit int,
+) ([]searchHit, error) {
+	var hits []searchHit
+
+	for page := 0; len(hits) < limit; page++ {
+		result, err := service.Search(ctx, options, page, min(limit, maxHitsPerPage))
+		if err != nil {
+			return nil, err
+		}
+
+		for i, it := range result.Items {
+			hits = append(hits, searchHit{
+				ID:       result.ObjectIDs[i],
+				StoryID:  it.ID,
+				Type:     it.Type,
+				Title:    it.Title,
+				URL:      it.URL,
+				Domain:   it.Domain,
+				Author:   it.User,
+				Points:   it.Points,
+				Comments: it.CommentsCount,
+				Time:     time.Unix(it.Time, 0),
+				Text:     it.Content,
+			})
+		}
+
+		if page+1 >= result.NbPages {
+			break
+		}
+	}
+
+	return hits[0:min(limit, len(hits))], nil
+}
+
+var searchColumns = []column[searchHit]{
+	{name: "id", value: func(hit searchHit) string { return strconv.Itoa(hit.ID) }},
+	{name: "points", value: func(hit searchHit) string { return strconv.Itoa(hit.Points) }},
+	{name: "comments", value: func(hit searchHit) string { return strconv.Itoa(hit.Comments) }},
+	{name: "author", value: func(hit searchHit) string { return hit.Author }},
+	{name: "date", value: func(hit searchHit) string { return hit.Time.Format("2006-01-02") }},
+	{name: "title", value: func(hit searchHit) string { return hit.Title }},
+}
+
+// parseDate accepts either a date or a period that is counted back from now.
+// Periods are Go durations with the addition of days, e.g. 7d.
+func parseDate(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+
+	if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && strings.HasSuffix(value, "d") {
+		return now.AddDate(0, 0, -days), nil
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse '%s' as a date or period", value)
+	}
+
+	return now.Add(-period), nil
+}
+
+// exitWithError writes to stderr so that the error does not end up in output
+// that is piped to another program.
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
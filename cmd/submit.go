@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"clx/bubble"
+	"clx/composer"
+
+	"github.com/spf13/cobra"
+)
+
+func submitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a story",
+		Long: "Write a submission in $EDITOR, preview it and post it to Hacker News. Fill in the title and " +
+			"the URL at the top and optionally a text below them. The draft is kept in " +
+			"~/.cache/circumflex/drafts until it has been posted.",
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig()
+			setIndentationSymbol(config)
+			configureHTTPClient(config)
+
+			bubble.RunComposer(config, composer.NewSubmission())
+		},
+	}
+}
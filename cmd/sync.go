@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/offline"
+
+	"github.com/spf13/cobra"
+)
+
+func syncCmd() *cobra.Command {
+	var (
+		categories   []string
+		limit        int
+		withArticles bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Download stories, comments and articles for reading offline",
+		Long: "Download the stories of one or more categories along with their comment sections and, with " +
+			"--with-articles, their articles. Browse the snapshot with 'clx --offline'. Each sync replaces the " +
+			"previous snapshot.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if limit < 1 {
+				exitWithError(fmt.Errorf("limit must be at least 1, got %d", limit))
+			}
+
+			options := offline.Options{
+				Limit:        limit,
+				WithArticles: withArticles,
+				Report: func(err error) {
+					fmt.Fprintln(os.Stderr, err)
+				},
+			}
+
+			for _, name := range categories {
+				cat, ok := category.FromName(name)
+				if !ok {
+					exitWithError(fmt.Errorf("unknown category '%s', expected one of: %s", name,
+						strings.Join(category.Names(), ", ")))
+				}
+
+				if cat == category.Favorites {
+					options.Favorites = loadFavorites().GetItems()
+
+					continue
+				}
+
+				options.Categories = append(options.Categories, cat)
+			}
+
+			config := getConfig()
+
+			// Syncing always goes to the network, and stale responses from
+			// the cache would end up in the snapshot
+			config.Offline = false
+			config.DisableCache = true
+
+			service := getService(config)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			fmt.Printf("Downloading to %s\n", config.OfflineDirectory)
+
+			summary, err := offline.Sync(ctx, service, config.OfflineDirectory, options)
+			if err != nil {
+				println("Could not sync: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
+
+			fmt.Printf("Downloaded %d stories, %d comment sections and %d articles", summary.Stories,
+				summary.Threads, summary.Articles)
+
+			if summary.Failed > 0 {
+				fmt.Printf(" (%d failed)", summary.Failed)
+			}
+
+			fmt.Println()
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&categories, "categories", []string{"top"}, "download these categories: "+
+		strings.Join(category.Names(), ", "))
+	cmd.Flags().IntVar(&limit, "limit", 30, "set the number of stories to download per category")
+	cmd.Flags().BoolVar(&withArticles, "with-articles", false, "also download the linked articles for reader mode")
+	_ = cmd.RegisterFlagCompletionFunc("categories", fixedCompletions(category.Names()...))
+
+	return cmd
+}
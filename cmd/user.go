@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"clx/bubble"
+	"clx/less"
+	"clx/user"
+
+	"github.com/spf13/cobra"
+)
+
+func userCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "user",
+		Short: "Show the profile of a user",
+		Long: "Show the karma, account age and submissions of a user. Press p to read the full profile and " +
+			"Enter to open any of the submissions",
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !user.IsValidName(args[0]) {
+				exitWithError(fmt.Errorf("'%s' is not a valid username", args[0]))
+			}
+
+			config := getConfig()
+			setIndentationSymbol(config)
+
+			verifyLess(config.NoLessVerify)
+
+			lesskey := less.NewLesskeyWithCommentSection()
+			config.LesskeyPath = lesskey.GetPath()
+			config.CommentSectionLesskeyPath = lesskey.GetCommentSectionPath()
+			defer lesskey.Remove()
+
+			service := getService(config)
+
+			bubble.RunProfile(config, service, args[0])
+		},
+	}
+}
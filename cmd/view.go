@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	_ "embed"
-	"strconv"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"clx/export"
 	"clx/less"
 
-	"clx/hn/services/hybrid"
+	"clx/hn"
+	"clx/item"
 
 	"clx/cli"
 	"clx/screen"
@@ -17,34 +22,93 @@ import (
 )
 
 func viewCmd() *cobra.Command {
-	return &cobra.Command{
+	var format string
+
+	cmd := &cobra.Command{
 		Use:   "view",
 		Short: "Go directly to the comment section by ID",
 		Long: "Directly enter the comment section for a given item without going through the main " +
-			"view first",
+			"view first. The item can be given as an ID or as a Hacker News or Algolia URL. For comments, the " +
+			"thread is narrowed down to the comment, its replies and its ancestors. With --export, the thread " +
+			"is written to stdout as Markdown, HTML or JSON instead.",
 		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completeItemIDs,
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			id, _ := strconv.Atoi(args[0])
-
-			service := new(hybrid.Service)
+			id := parseID(args[0])
 
-			comments := service.FetchComments(id)
+			if format != "" && !contains(export.Formats(), format) {
+				exitWithError(fmt.Errorf("unknown export format '%s', expected one of: %s", format,
+					strings.Join(export.Formats(), ", ")))
+			}
 
 			config := getConfig()
 
+			service := getService(config)
+
+			comments, err := fetchThread(context.Background(), service, id)
+			if err != nil {
+				println("Could not fetch comments: " + hn.ErrorMessage(err))
+				os.Exit(1)
+			}
+
+			if format != "" {
+				if err := export.Write(os.Stdout, comments, format); err != nil {
+					exitWithError(err)
+				}
+
+				return
+			}
+
 			screenWidth := screen.GetTerminalWidth()
 			commentTree := tree.Print(comments, config, screenWidth, time.Now().Unix())
 
 			lesskey := less.NewLesskey()
 			config.LesskeyPath = lesskey.GetPath()
+			defer lesskey.Remove()
 
 			command := cli.Less(commentTree, config)
 
 			if err := command.Run(); err != nil {
-				defer lesskey.Remove()
 				panic(err)
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&format, "export", "", "write the thread to stdout as '"+
+		strings.Join(export.Formats(), "', '")+"'")
+	_ = cmd.RegisterFlagCompletionFunc("export", fixedCompletions(export.Formats()...))
+
+	return cmd
+}
+
+// fetchThread fetches the comments of the story that the item belongs to. If
+// the item is a comment, only the comment, its replies and its ancestors are
+// kept.
+func fetchThread(ctx context.Context, service hn.Service, id int) (*item.Item, error) {
+	story, path, err := hn.FindStory(ctx, service, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := service.FetchComments(ctx, story.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return comments, nil
+	}
+
+	return hn.Focus(comments, path)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
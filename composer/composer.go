@@ -0,0 +1,165 @@
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"clx/cli"
+	"clx/comment"
+	"clx/constants/unicode"
+	"clx/file"
+	"clx/hn"
+	"clx/session"
+	"clx/settings"
+
+	. "github.com/logrusorgru/aurora/v3"
+)
+
+const submissionTemplate = "Title: \nURL: \n\n"
+
+// Draft is a reply or a submission that is written in $EDITOR. The text is
+// kept on disk until it has been posted so that nothing is lost if posting
+// fails.
+type Draft struct {
+	Path        string
+	Description string
+	template    string
+	post        func(ctx context.Context, s *session.Session, text string) error
+}
+
+func NewReply(id int) *Draft {
+	return &Draft{
+		Path:        PathToDraft(fmt.Sprintf("reply-%d", id)),
+		Description: fmt.Sprintf("Reply to item %d", id),
+		post: func(ctx context.Context, s *session.Session, text string) error {
+			return s.Reply(ctx, id, Format(text))
+		},
+	}
+}
+
+// NewSubmission returns a draft that starts with a title and a URL field.
+// The text below them is optional if a URL is given.
+func NewSubmission() *Draft {
+	return &Draft{
+		Path:        PathToDraft("submission"),
+		Description: "New submission",
+		template:    submissionTemplate,
+		post: func(ctx context.Context, s *session.Session, text string) error {
+			title, url, body := parseSubmission(text)
+			if title == "" {
+				return errors.New("the submission has no title")
+			}
+
+			return s.Submit(ctx, title, url, Format(body))
+		},
+	}
+}
+
+func PathToDraft(name string) string {
+	return path.Join(file.PathToCacheDirectory(), "drafts", name+".txt")
+}
+
+// Edit returns the command that opens the draft in the editor. The draft is
+// created from its template first unless it already exists from an earlier
+// attempt.
+func (d *Draft) Edit() (*exec.Cmd, error) {
+	if !file.Exists(d.Path) {
+		if err := file.WriteToFileNew(path.Dir(d.Path), path.Base(d.Path), d.template); err != nil {
+			return nil, fmt.Errorf("could not create draft: %w", err)
+		}
+	}
+
+	return cli.Editor(d.Path), nil
+}
+
+// Read returns the text of the draft, or an empty string if nothing has been
+// written besides the template.
+func (d *Draft) Read() (string, error) {
+	content, err := os.ReadFile(d.Path)
+	if err != nil {
+		return "", fmt.Errorf("could not read draft: %w", err)
+	}
+
+	text := strings.TrimSpace(string(content))
+	if text == strings.TrimSpace(d.template) {
+		return "", nil
+	}
+
+	return text, nil
+}
+
+func (d *Draft) Remove() error {
+	if err := os.Remove(d.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove draft: %w", err)
+	}
+
+	return nil
+}
+
+// Post posts the text and removes the draft on success.
+func (d *Draft) Post(ctx context.Context, s *session.Session, text string) error {
+	if err := d.post(ctx, s, text); err != nil {
+		return err
+	}
+
+	return d.Remove()
+}
+
+// Preview renders the text the way it will appear in the comment section.
+func (d *Draft) Preview(text string, config *settings.Config) string {
+	header := unicode.ZeroWidthSpace + "\n" + Bold(d.Description).String() + "\n\n"
+
+	if d.template == submissionTemplate {
+		title, url, body := parseSubmission(text)
+		header += Bold(title).String() + "\n" + Blue(url).String() + "\n\n"
+		text = body
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return header
+	}
+
+	return header + comment.Print(ToHTML(Format(text)), config, config.CommentWidth, config.CommentWidth) + "\n"
+}
+
+// ErrorMessage describes why posting failed in a way that fits the status
+// bar.
+func ErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, session.ErrNotLoggedIn):
+		return "Not logged in, run 'clx login' first"
+
+	case errors.Is(err, session.ErrUnavailable):
+		return "Cannot reply to this item"
+
+	case errors.Is(err, session.ErrRejected):
+		return err.Error()
+
+	default:
+		return hn.ErrorMessage(err)
+	}
+}
+
+func parseSubmission(text string) (title string, url string, body string) {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "Title:"):
+			title = strings.TrimSpace(strings.TrimPrefix(line, "Title:"))
+
+		case strings.HasPrefix(line, "URL:"):
+			url = strings.TrimSpace(strings.TrimPrefix(line, "URL:"))
+
+		default:
+			return title, url, strings.TrimSpace(strings.Join(lines[i:], "\n"))
+		}
+	}
+
+	return title, url, ""
+}
@@ -0,0 +1,144 @@
+package composer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	link       = regexp.MustCompile(`\[([^\]]+)\]\((\S+?)\)`)
+	bold       = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	underscore = regexp.MustCompile(`\b_([^_\s][^_]*?)_\b`)
+	bareURL    = regexp.MustCompile(`https?://[^\s<]+[^\s<.,:;!?)]`)
+	emphasis   = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	listItem   = regexp.MustCompile(`^([-+*]|\d+[.)])\s`)
+)
+
+// Format converts markdown-ish text into the plain text format that HN
+// expects in comments: paragraphs are separated by blank lines, emphasis is
+// written as *text* and code is indented by two spaces. Line breaks within a
+// paragraph are joined since HN would ignore them anyway.
+func Format(input string) string {
+	f := new(formatter)
+	isInFence := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		isIndented := strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t")
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			f.flush()
+			isInFence = !isInFence
+
+		case isInFence:
+			f.add("  "+strings.TrimRight(line, " "), true)
+
+		case trimmed == "":
+			f.flush()
+
+		case isIndented && (len(f.lines) == 0 || f.isCode):
+			f.add(strings.ReplaceAll(strings.TrimRight(line, " "), "\t", "    "), true)
+
+		case strings.HasPrefix(trimmed, "#"):
+			f.flush()
+			f.add(formatInline(strings.TrimLeft(trimmed, "# ")), false)
+			f.flush()
+
+		case listItem.MatchString(trimmed) || strings.HasPrefix(trimmed, ">"):
+			// HN has no lists, so each item and quote gets its own paragraph
+			f.flush()
+			f.add(formatInline(trimmed), false)
+
+		default:
+			f.add(formatInline(trimmed), false)
+		}
+	}
+
+	f.flush()
+
+	return strings.Join(f.paragraphs, "\n\n")
+}
+
+type formatter struct {
+	paragraphs []string
+	lines      []string
+	isCode     bool
+}
+
+func (f *formatter) add(line string, isCode bool) {
+	if isCode != f.isCode {
+		f.flush()
+	}
+
+	f.isCode = isCode
+	f.lines = append(f.lines, line)
+}
+
+func (f *formatter) flush() {
+	if len(f.lines) == 0 {
+		return
+	}
+
+	separator := " "
+	if f.isCode {
+		separator = "\n"
+	}
+
+	f.paragraphs = append(f.paragraphs, strings.Join(f.lines, separator))
+	f.lines = nil
+	f.isCode = false
+}
+
+func formatInline(line string) string {
+	line = link.ReplaceAllStringFunc(line, func(match string) string {
+		parts := link.FindStringSubmatch(match)
+		if parts[1] == parts[2] {
+			return parts[2]
+		}
+
+		return parts[1] + " (" + parts[2] + ")"
+	})
+
+	line = bold.ReplaceAllString(line, "*$1$2*")
+	line = underscore.ReplaceAllString(line, "*$1*")
+
+	return line
+}
+
+// ToHTML renders text in HN's comment format the way HN displays it, which
+// is the format that comment.Print expects.
+func ToHTML(text string) string {
+	var sb strings.Builder
+
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+	for i, paragraph := range strings.Split(escape.Replace(text), "\n\n") {
+		if i > 0 {
+			sb.WriteString("<p>")
+		}
+
+		if isCode(paragraph) {
+			sb.WriteString("<pre><code>" + paragraph + "\n</code></pre>")
+
+			continue
+		}
+
+		paragraph = emphasis.ReplaceAllString(paragraph, "<i>$1</i>")
+		paragraph = bareURL.ReplaceAllString(paragraph, `<a href="$0" rel="nofollow">$0</a>`)
+
+		sb.WriteString(paragraph)
+	}
+
+	return sb.String()
+}
+
+func isCode(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !strings.HasPrefix(line, "  ") {
+			return false
+		}
+	}
+
+	return true
+}
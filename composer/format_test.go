@@ -0,0 +1,46 @@
+package composer_test
+
+import (
+	"testing"
+
+	"clx/composer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	input := "# Thoughts\n" +
+		"This is **important** and\n" +
+		"_subtle_, see [the docs](https://example.com).\n" +
+		"\n" +
+		"- first\n" +
+		"- second\n" +
+		"\n" +
+		"```\n" +
+		"func main() {}\n" +
+		"```\n" +
+		"> a quote"
+
+	expected := "Thoughts\n\n" +
+		"This is *important* and *subtle*, see the docs (https://example.com).\n\n" +
+		"- first\n\n" +
+		"- second\n\n" +
+		"  func main() {}\n\n" +
+		"> a quote"
+
+	assert.Equal(t, expected, composer.Format(input))
+}
+
+func TestToHTML(t *testing.T) {
+	t.Parallel()
+
+	input := "A <b> *b*\n\n  x := 1\n\nhttps://example.com/a."
+
+	expected := "A &lt;b&gt; <i>b</i>" +
+		"<p><pre><code>  x := 1\n</code></pre>" +
+		`<p><a href="https://example.com/a" rel="nofollow">https://example.com/a</a>.`
+
+	assert.Equal(t, expected, composer.ToHTML(input))
+}
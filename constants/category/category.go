@@ -5,6 +5,46 @@ const (
 	New       = 1
 	Ask       = 2
 	Show      = 3
-	Favorites = 4
-	Buffer    = 5
+	Best      = 4
+	Jobs      = 5
+	Launch    = 6
+	Favorites = 7
+	Buffer    = 8
+	Search    = 9
 )
+
+// names maps the names used on the command line to the categories that can
+// be listed
+var names = []struct {
+	name     string
+	category int
+}{
+	{name: "top", category: FrontPage},
+	{name: "new", category: New},
+	{name: "ask", category: Ask},
+	{name: "show", category: Show},
+	{name: "best", category: Best},
+	{name: "jobs", category: Jobs},
+	{name: "launch", category: Launch},
+	{name: "favorites", category: Favorites},
+}
+
+func Names() []string {
+	n := make([]string, 0, len(names))
+
+	for _, c := range names {
+		n = append(n, c.name)
+	}
+
+	return n
+}
+
+func FromName(name string) (int, bool) {
+	for _, c := range names {
+		if c.name == name {
+			return c.category, true
+		}
+	}
+
+	return 0, false
+}
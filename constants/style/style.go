@@ -10,6 +10,8 @@ const (
 	yellowDark  = "214"
 	blueDark    = "33"
 	pinkDark    = "219"
+	greenDark   = "78"
+	cyanDark    = "44"
 
 	orange      = "214"
 	orangeFaint = "94"
@@ -25,6 +27,8 @@ const (
 	yellowLight  = "208"
 	blueLight    = blueDark
 	pinkLight    = pinkDark
+	greenLight   = "28"
+	cyanLight    = "30"
 
 	logoBgLight           = "252"
 	headerBgLight         = "254"
@@ -50,6 +54,14 @@ func GetPink() lipgloss.TerminalColor {
 	return lipgloss.AdaptiveColor{Light: pinkLight, Dark: pinkDark}
 }
 
+func GetGreen() lipgloss.TerminalColor {
+	return lipgloss.AdaptiveColor{Light: greenLight, Dark: greenDark}
+}
+
+func GetCyan() lipgloss.TerminalColor {
+	return lipgloss.AdaptiveColor{Light: cyanLight, Dark: cyanDark}
+}
+
 func GetOrange() lipgloss.TerminalColor {
 	return lipgloss.AdaptiveColor{Light: orange, Dark: orange}
 }
@@ -2,6 +2,13 @@ package endpoints
 
 import "time"
 
+const (
+	FirebaseURL   = "https://hacker-news.firebaseio.com/v0"
+	AlgoliaURL    = "https://hn.algolia.com/api/v1"
+	HackerWebURL  = "http://api.hackerwebapp.com"
+	HackerNewsURL = "https://news.ycombinator.com"
+)
+
 type Story struct {
 	ID            int    `json:"id"`
 	Title         string `json:"title"`
@@ -16,16 +23,41 @@ type Story struct {
 
 type HN struct {
 	By          string `json:"by"`
+	Dead        bool   `json:"dead"`
+	Deleted     bool   `json:"deleted"`
 	Descendants int    `json:"descendants"`
 	Id          int    `json:"id"`
 	Kids        []int  `json:"kids"`
+	Parent      int    `json:"parent"`
+	Parts       []int  `json:"parts"`
 	Score       int    `json:"score"`
+	Text        string `json:"text"`
 	Time        int    `json:"time"`
 	Title       string `json:"title"`
 	Type        string `json:"type"`
 	Url         string `json:"url"`
 }
 
+type HNUpdates struct {
+	Items    []int    `json:"items"`
+	Profiles []string `json:"profiles"`
+}
+
+type HNUser struct {
+	About     string `json:"about"`
+	Created   int    `json:"created"`
+	ID        string `json:"id"`
+	Karma     int    `json:"karma"`
+	Submitted []int  `json:"submitted"`
+}
+
+type AlgoliaUser struct {
+	Username   string `json:"username"`
+	About      string `json:"about"`
+	Karma      int    `json:"karma"`
+	CreatedAtI int    `json:"created_at_i"`
+}
+
 type Comments struct {
 	ID            int        `json:"id"`
 	Title         string     `json:"title"`
@@ -40,51 +72,73 @@ type Comments struct {
 	Comments      []Comments `json:"comments"`
 	Content       string     `json:"content"`
 	CommentsCount int        `json:"comments_count"`
+	Poll          []struct {
+		Item   string `json:"item"`
+		Points int    `json:"points"`
+	} `json:"poll"`
 }
 
 type Algolia struct {
-	Hits []struct {
-		CreatedAt       time.Time   `json:"created_at"`
-		Title           string      `json:"title"`
-		URL             string      `json:"url"`
-		Author          string      `json:"author"`
-		Points          int         `json:"points"`
-		StoryText       interface{} `json:"story_text"`
-		CommentText     interface{} `json:"comment_text"`
-		NumComments     int         `json:"num_comments"`
-		StoryID         interface{} `json:"story_id"`
-		StoryTitle      interface{} `json:"story_title"`
-		StoryURL        interface{} `json:"story_url"`
-		ParentID        interface{} `json:"parent_id"`
-		CreatedAtI      int         `json:"created_at_i"`
-		Tags            []string    `json:"_tags"`
-		ObjectID        string      `json:"objectID"`
-		HighlightResult struct {
-			Title struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"title"`
-			URL struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"url"`
-			Author struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"author"`
-		} `json:"_highlightResult"`
-	} `json:"hits"`
-	NbHits           int      `json:"nbHits"`
-	Page             int      `json:"page"`
-	NbPages          int      `json:"nbPages"`
-	HitsPerPage      int      `json:"hitsPerPage"`
-	ExhaustiveNbHits bool     `json:"exhaustiveNbHits"`
-	ExhaustiveTypo   bool     `json:"exhaustiveTypo"`
-	Query            string   `json:"query"`
-	Params           string   `json:"params"`
-	RenderingContent struct{} `json:"renderingContent"`
-	ProcessingTimeMS int      `json:"processingTimeMS"`
+	Hits             []AlgoliaHit `json:"hits"`
+	NbHits           int          `json:"nbHits"`
+	Page             int          `json:"page"`
+	NbPages          int          `json:"nbPages"`
+	HitsPerPage      int          `json:"hitsPerPage"`
+	ExhaustiveNbHits bool         `json:"exhaustiveNbHits"`
+	ExhaustiveTypo   bool         `json:"exhaustiveTypo"`
+	Query            string       `json:"query"`
+	Params           string       `json:"params"`
+	RenderingContent struct{}     `json:"renderingContent"`
+	ProcessingTimeMS int          `json:"processingTimeMS"`
+}
+
+type AlgoliaHit struct {
+	CreatedAt       time.Time   `json:"created_at"`
+	Title           string      `json:"title"`
+	URL             string      `json:"url"`
+	Author          string      `json:"author"`
+	Points          int         `json:"points"`
+	StoryText       interface{} `json:"story_text"`
+	CommentText     interface{} `json:"comment_text"`
+	NumComments     int         `json:"num_comments"`
+	StoryID         interface{} `json:"story_id"`
+	StoryTitle      interface{} `json:"story_title"`
+	StoryURL        interface{} `json:"story_url"`
+	ParentID        interface{} `json:"parent_id"`
+	CreatedAtI      int         `json:"created_at_i"`
+	Tags            []string    `json:"_tags"`
+	ObjectID        string      `json:"objectID"`
+	HighlightResult struct {
+		Title struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"title"`
+		URL struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"url"`
+		Author struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"author"`
+	} `json:"_highlightResult"`
+}
+
+type AlgoliaItem struct {
+	ID         int           `json:"id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	CreatedAtI int           `json:"created_at_i"`
+	Type       string        `json:"type"`
+	Author     string        `json:"author"`
+	Title      string        `json:"title"`
+	URL        string        `json:"url"`
+	Text       string        `json:"text"`
+	Points     int           `json:"points"`
+	ParentID   int           `json:"parent_id"`
+	StoryID    int           `json:"story_id"`
+	Children   []AlgoliaItem `json:"children"`
+	Options    []AlgoliaItem `json:"options"`
 }
@@ -0,0 +1,215 @@
+// Package export writes comment threads to Markdown, HTML and JSON for
+// archiving.
+//
+// The JSON form follows a versioned schema that does not change with the
+// internal item model:
+//
+//	{
+//	  "schema_version": 1,
+//	  "id": 123,                    // HN item ID
+//	  "type": "story",              // story, ask, job, poll, ...
+//	  "title": "...",
+//	  "url": "https://...",         // omitted for text posts
+//	  "hn_url": "https://news.ycombinator.com/item?id=123",
+//	  "author": "...",
+//	  "points": 42,
+//	  "comments_count": 7,
+//	  "time": "2022-01-26T16:38:26Z", // RFC 3339, UTC
+//	  "html": "...",                // text of the post, omitted if empty
+//	  "comments": [Comment]
+//	}
+//
+// Each Comment has the fields id, hn_url, author, time, html, deleted and
+// replies, where replies is a list of Comments.
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/endpoints"
+	"clx/item"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+
+	SchemaVersion = 1
+
+	deleted = "[deleted]"
+)
+
+type Thread struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            int       `json:"id"`
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	URL           string    `json:"url,omitempty"`
+	HNURL         string    `json:"hn_url"`
+	Author        string    `json:"author"`
+	Points        int       `json:"points"`
+	CommentsCount int       `json:"comments_count"`
+	Time          time.Time `json:"time"`
+	HTML          string    `json:"html,omitempty"`
+	Comments      []Comment `json:"comments"`
+}
+
+type Comment struct {
+	ID      int       `json:"id"`
+	HNURL   string    `json:"hn_url"`
+	Author  string    `json:"author,omitempty"`
+	Time    time.Time `json:"time"`
+	HTML    string    `json:"html,omitempty"`
+	Deleted bool      `json:"deleted"`
+	Replies []Comment `json:"replies"`
+}
+
+func Formats() []string {
+	return []string{FormatMarkdown, FormatHTML, FormatJSON}
+}
+
+// FileName returns the name that exports of the item are saved under.
+func FileName(id int, format string) string {
+	return fmt.Sprintf("hn-%d.%s", id, format)
+}
+
+// Create creates a new file in directory to export the item to. Earlier
+// exports are kept by numbering the name, e.g. hn-123-1.md.
+func Create(directory string, id int, format string) (*os.File, error) {
+	name := FileName(id, format)
+
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(filepath.Join(directory, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+
+		name = fmt.Sprintf("hn-%d-%d.%s", id, i, format)
+	}
+}
+
+func Write(w io.Writer, story *item.Item, format string) error {
+	thread := NewThread(story)
+
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdown(w, thread)
+
+	case FormatHTML:
+		return writeHTML(w, thread)
+
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(thread); err != nil {
+			return fmt.Errorf("could not encode thread: %w", err)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown export format '%s', expected one of: %s", format, strings.Join(Formats(), ", "))
+	}
+}
+
+func NewThread(story *item.Item) *Thread {
+	return &Thread{
+		SchemaVersion: SchemaVersion,
+		ID:            story.ID,
+		Type:          story.Type,
+		Title:         story.Title,
+		URL:           story.URL,
+		HNURL:         itemURL(story.ID),
+		Author:        story.User,
+		Points:        story.Points,
+		CommentsCount: story.CommentsCount,
+		Time:          time.Unix(story.Time, 0).UTC(),
+		HTML:          normalizeHTML(story.Content),
+		Comments:      newComments(story.Comments),
+	}
+}
+
+func newComments(items []*item.Item) []Comment {
+	comments := make([]Comment, 0, len(items))
+
+	for _, it := range items {
+		c := Comment{
+			ID:      it.ID,
+			HNURL:   itemURL(it.ID),
+			Author:  it.User,
+			Time:    time.Unix(it.Time, 0).UTC(),
+			Deleted: it.Content == deleted,
+			Replies: newComments(it.Comments),
+		}
+
+		if !c.Deleted {
+			c.HTML = normalizeHTML(it.Content)
+		}
+
+		comments = append(comments, c)
+	}
+
+	return comments
+}
+
+func itemURL(id int) string {
+	return endpoints.HackerNewsURL + "/item?id=" + strconv.Itoa(id)
+}
+
+// normalizeHTML closes the paragraphs in HN's HTML, which only opens them
+// with <p>, so that the text is valid on its own. Code blocks are left outside
+// of paragraphs, since <p> cannot contain <pre>.
+func normalizeHTML(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+
+	text = strings.ReplaceAll(text, "\n</code></pre>", "</code></pre>")
+
+	if strings.Contains(text, "</p>") {
+		return text
+	}
+
+	var sb strings.Builder
+
+	for text != "" {
+		before, rest, hasCode := strings.Cut(text, "<pre>")
+
+		writeParagraphs(&sb, before)
+
+		if !hasCode {
+			break
+		}
+
+		code, after, _ := strings.Cut(rest, "</pre>")
+
+		sb.WriteString("<pre>" + code + "</pre>")
+
+		text = after
+	}
+
+	return sb.String()
+}
+
+func writeParagraphs(sb *strings.Builder, text string) {
+	for _, paragraph := range strings.Split(text, "<p>") {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+
+		sb.WriteString("<p>" + paragraph + "</p>")
+	}
+}
@@ -0,0 +1,124 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/export"
+	"clx/item"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getThread() *item.Item {
+	return &item.Item{
+		ID:            1,
+		Type:          "story",
+		Title:         "Title",
+		URL:           "https://example.com",
+		User:          "alfa",
+		Points:        10,
+		Time:          1643215106,
+		CommentsCount: 3,
+		Comments: []*item.Item{
+			{
+				ID:      2,
+				User:    "beta",
+				Time:    1643215106,
+				Content: "<p>First <i>point</i><p><pre><code>  x := 1\n</code></pre><p>Last",
+				Comments: []*item.Item{
+					{ID: 3, User: "gamma", Time: 1643215106, Content: "<p>Reply <script>alert(1)</script>"},
+				},
+			},
+			{ID: 4, Time: 1643215106, Content: "[deleted]"},
+		},
+	}
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	assert.NoError(t, export.Write(&b, getThread(), export.FormatJSON))
+
+	var thread export.Thread
+
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &thread))
+	assert.Equal(t, export.SchemaVersion, thread.SchemaVersion)
+	assert.Equal(t, "2022-01-26T16:38:26Z", thread.Time.Format("2006-01-02T15:04:05Z07:00"))
+	assert.Len(t, thread.Comments, 2)
+	assert.Equal(t, "https://news.ycombinator.com/item?id=2", thread.Comments[0].HNURL)
+	assert.Equal(t, "<p>First <i>point</i></p><pre><code>  x := 1</code></pre><p>Last</p>", thread.Comments[0].HTML)
+	assert.Equal(t, "gamma", thread.Comments[0].Replies[0].Author)
+	assert.True(t, thread.Comments[1].Deleted)
+	assert.Empty(t, thread.Comments[1].HTML)
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	assert.NoError(t, export.Write(&b, getThread(), export.FormatMarkdown))
+
+	out := b.String()
+
+	assert.Contains(t, out, "# [Title](https://example.com)")
+	assert.Contains(t, out, "**beta** · [2022-01-26 16:38 UTC](https://news.ycombinator.com/item?id=2)")
+	assert.Contains(t, out, "First _point_")
+	assert.Contains(t, out, "```\n  x := 1\n```")
+	assert.Contains(t, out, "> **gamma**")
+	assert.Contains(t, out, "*[deleted]*")
+}
+
+func TestHTMLIsSanitized(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	assert.NoError(t, export.Write(&b, getThread(), export.FormatHTML))
+
+	assert.Contains(t, b.String(), "<i>point</i>")
+	assert.NotContains(t, b.String(), "<script>")
+}
+
+func TestCreateKeepsEarlierExports(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	earlier := filepath.Join(directory, "hn-1.md")
+
+	assert.NoError(t, os.WriteFile(earlier, []byte("Earlier"), 0o600))
+
+	for _, name := range []string{"hn-1-1.md", "hn-1-2.md"} {
+		f, err := export.Create(directory, 1, export.FormatMarkdown)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(directory, name), f.Name())
+		assert.NoError(t, f.Close())
+	}
+
+	content, err := os.ReadFile(earlier)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Earlier", string(content))
+}
+
+func TestMarkdownEscapesTitlesAndAuthors(t *testing.T) {
+	t.Parallel()
+
+	thread := getThread()
+	thread.Title = "Show HN: [Beta] snake_case"
+	thread.Comments[0].User = "user_name_"
+
+	var b bytes.Buffer
+
+	assert.NoError(t, export.Write(&b, thread, export.FormatMarkdown))
+
+	assert.Contains(t, b.String(), `# [Show HN: \[Beta\] snake\_case](https://example.com)`)
+	assert.Contains(t, b.String(), `**user\_name\_** ·`)
+}
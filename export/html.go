@@ -0,0 +1,84 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var page = template.Must(template.New("thread").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Thread.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; line-height: 1.5; }
+.meta { color: #666; font-size: 0.9em; }
+.replies { margin-left: 1.5em; padding-left: 1em; border-left: 2px solid #ddd; }
+pre { overflow-x: auto; background: #f6f6f6; padding: 0.5em; }
+</style>
+</head>
+<body>
+<article>
+<h1>{{if .Thread.URL}}<a href="{{.Thread.URL}}">{{.Thread.Title}}</a>{{else}}{{.Thread.Title}}{{end}}</h1>
+<p class="meta">{{.Thread.Points}} points by <b>{{.Thread.Author}}</b> on <a href="{{.Thread.HNURL}}">` +
+	`{{.Thread.Time.Format "2006-01-02 15:04 MST"}}</a> · {{.Thread.CommentsCount}} comments</p>
+{{.Text}}
+</article>
+<section class="comments">
+{{template "comments" .Comments}}
+</section>
+</body>
+</html>
+{{define "comments"}}{{range .}}<div class="comment" id="{{.ID}}">
+<p class="meta"><b>{{.Author}}</b> · <a href="{{.HNURL}}">{{.Time.Format "2006-01-02 15:04 MST"}}</a></p>
+{{if .Deleted}}<p><i>[deleted]</i></p>{{else}}{{.Text}}{{end}}
+{{if .Replies}}<div class="replies">
+{{template "comments" .Replies}}</div>
+{{end}}</div>
+{{end}}{{end}}
+`))
+
+// htmlComment carries the sanitized text of a comment, which is the only
+// HTML that is not escaped by the template.
+type htmlComment struct {
+	Comment
+	Text    template.HTML
+	Replies []htmlComment
+}
+
+func writeHTML(w io.Writer, thread *Thread) error {
+	policy := bluemonday.UGCPolicy()
+
+	data := struct {
+		Thread   *Thread
+		Text     template.HTML
+		Comments []htmlComment
+	}{
+		Thread:   thread,
+		Text:     template.HTML(policy.Sanitize(thread.HTML)),
+		Comments: newHTMLComments(thread.Comments, policy),
+	}
+
+	if err := page.Execute(w, data); err != nil {
+		return fmt.Errorf("could not write thread: %w", err)
+	}
+
+	return nil
+}
+
+func newHTMLComments(comments []Comment, policy *bluemonday.Policy) []htmlComment {
+	htmlComments := make([]htmlComment, 0, len(comments))
+
+	for _, c := range comments {
+		htmlComments = append(htmlComments, htmlComment{
+			Comment: c,
+			Text:    template.HTML(policy.Sanitize(c.HTML)),
+			Replies: newHTMLComments(c.Replies, policy),
+		})
+	}
+
+	return htmlComments
+}
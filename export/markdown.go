@@ -0,0 +1,84 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"clx/reader/markdown/document"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+)
+
+const timeFormat = "2006-01-02 15:04 MST"
+
+// writeMarkdown writes the post followed by the comments. Replies are nested
+// in block quotes, one level per reply, which keeps the structure readable in
+// any Markdown renderer.
+func writeMarkdown(w io.Writer, thread *Thread) error {
+	converter := md.NewConverter("", true, &md.Options{CodeBlockStyle: "fenced"})
+
+	var sb strings.Builder
+
+	title := document.Escape(thread.Title)
+	if thread.URL != "" {
+		title = fmt.Sprintf("[%s](%s)", title, thread.URL)
+	}
+
+	sb.WriteString("# " + title + "\n\n")
+	sb.WriteString(fmt.Sprintf("%d points by **%s** on [%s](%s) · %d comments\n", thread.Points,
+		document.Escape(thread.Author), thread.Time.Format(timeFormat), thread.HNURL, thread.CommentsCount))
+
+	if thread.HTML != "" {
+		text, err := converter.ConvertString(thread.HTML)
+		if err != nil {
+			return fmt.Errorf("could not convert post to Markdown: %w", err)
+		}
+
+		sb.WriteString("\n" + text + "\n")
+	}
+
+	sb.WriteString("\n---\n")
+
+	for _, c := range thread.Comments {
+		if err := writeMarkdownComment(&sb, converter, c, 0); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("could not write thread: %w", err)
+	}
+
+	return nil
+}
+
+func writeMarkdownComment(sb *strings.Builder, converter *md.Converter, c Comment, level int) error {
+	lines := fmt.Sprintf("*[deleted]* · [%s](%s)", c.Time.Format(timeFormat), c.HNURL)
+
+	if !c.Deleted {
+		text, err := converter.ConvertString(c.HTML)
+		if err != nil {
+			return fmt.Errorf("could not convert comment %d to Markdown: %w", c.ID, err)
+		}
+
+		lines = fmt.Sprintf("**%s** · [%s](%s)\n\n%s", document.Escape(c.Author), c.Time.Format(timeFormat),
+			c.HNURL, text)
+	}
+
+	prefix := strings.Repeat("> ", level)
+
+	sb.WriteString("\n")
+
+	for _, line := range strings.Split(lines, "\n") {
+		sb.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+	}
+
+	for _, reply := range c.Replies {
+		if err := writeMarkdownComment(sb, converter, reply, level+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
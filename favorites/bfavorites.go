@@ -13,31 +13,37 @@ type Favorites struct {
 	items []*item.Item
 }
 
-func New() *Favorites {
+func New() (*Favorites, error) {
 	favoritesPath := file.PathToFavoritesFile()
 
-	if file.Exists(favoritesPath) {
-		favoritesJSON, _ := os.ReadFile(favoritesPath)
-		items := unmarshal(favoritesJSON)
+	if !file.Exists(favoritesPath) {
+		return new(Favorites), nil
+	}
 
-		favoritesFromDisk := new(Favorites)
-		favoritesFromDisk.items = items
+	favoritesJSON, err := os.ReadFile(favoritesPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read favorites: %w", err)
+	}
 
-		return favoritesFromDisk
+	items, err := unmarshal(favoritesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", favoritesPath, err)
 	}
 
-	return new(Favorites)
+	favoritesFromDisk := new(Favorites)
+	favoritesFromDisk.items = items
+
+	return favoritesFromDisk, nil
 }
 
-func unmarshal(data []byte) []*item.Item {
+func unmarshal(data []byte) ([]*item.Item, error) {
 	var items []*item.Item
 
-	err := json.Unmarshal(data, &items)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
 	}
 
-	return items
+	return items, nil
 }
 
 func (f *Favorites) GetItems() []*item.Item {
@@ -52,33 +58,66 @@ func (f *Favorites) Add(item *item.Item) {
 	f.items = append(f.items, item)
 }
 
-func (f *Favorites) Write() {
-	err := file.WriteToFile(file.PathToFavoritesFile(), serializeToJson(f.items))
+// Index returns the position of the item with the given ID, or -1 if it is
+// not a favorite.
+func (f *Favorites) Index(id int) int {
+	for i, it := range f.items {
+		if it.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (f *Favorites) Write() error {
+	favoritesJSON, err := serializeToJson(f.items)
 	if err != nil {
-		panic(fmt.Errorf("could not write to file: %w", err))
+		return err
 	}
+
+	if err := file.WriteToFile(file.PathToFavoritesFile(), favoritesJSON); err != nil {
+		return fmt.Errorf("could not write favorites: %w", err)
+	}
+
+	return nil
 }
 
-func serializeToJson(favorites []*item.Item) string {
+func serializeToJson(favorites []*item.Item) (string, error) {
 	stream, err := json.MarshalIndent(favorites, "", "    ")
 	if err != nil {
-		panic(fmt.Errorf("could not serialize favorites struct: %w", err))
+		return "", fmt.Errorf("could not serialize favorites struct: %w", err)
 	}
 
-	return string(stream)
+	return string(stream), nil
 }
 
-func (f *Favorites) Remove(index int) {
-	if index < 0 || index > len(f.items) {
-		errorString := fmt.Sprintf("Out of bounds access for slice. Tried to remove index of %d, but size of "+
-			"slice was %d", index, len(f.items))
-		panic(errorString)
+func (f *Favorites) Remove(index int) error {
+	if index < 0 || index >= len(f.items) {
+		return fmt.Errorf("could not remove favorite %d, there are %d favorites", index+1, len(f.items))
 	}
 
 	f.items = append(f.items[:index], f.items[index+1:]...)
+
+	return nil
 }
 
-func (f *Favorites) UpdateStoryAndWriteToDisk(newItem *item.Item) {
+// Move moves the item at index from to index to, shifting the items in
+// between.
+func (f *Favorites) Move(from int, to int) error {
+	if from < 0 || from >= len(f.items) || to < 0 || to >= len(f.items) {
+		return fmt.Errorf("could not move favorite %d to %d, there are %d favorites", from+1, to+1, len(f.items))
+	}
+
+	moved := f.items[from]
+
+	f.items = append(f.items[:from], f.items[from+1:]...)
+	f.items = append(f.items[:to], append([]*item.Item{moved}, f.items[to:]...)...)
+
+	return nil
+}
+
+func (f *Favorites) UpdateStoryAndWriteToDisk(newItem *item.Item) error {
 	for i, s := range f.items {
 		if s.ID == newItem.ID {
 			isFieldsUpdated := s.Title != newItem.Title || s.Points != newItem.Points ||
@@ -95,8 +134,10 @@ func (f *Favorites) UpdateStoryAndWriteToDisk(newItem *item.Item) {
 				f.items[i].URL = newItem.URL
 				f.items[i].Domain = newItem.Domain
 
-				f.Write()
+				return f.Write()
 			}
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,164 @@
+package favorites
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/endpoints"
+	"clx/item"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatHTML = "html"
+	FormatOPML = "opml"
+)
+
+type record struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title"`
+	URL      string    `json:"url,omitempty"`
+	HNURL    string    `json:"hn_url"`
+	Author   string    `json:"author"`
+	Points   int       `json:"points"`
+	Comments int       `json:"comments"`
+	Time     time.Time `json:"time"`
+}
+
+// Bookmarks link to the discussion on Hacker News so that they can be
+// imported again
+var bookmarks = template.Must(template.New("bookmarks").Parse(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>circumflex favorites</TITLE>
+<H1>circumflex favorites</H1>
+<DL><p>
+{{- range .}}
+    <DT><A HREF="{{.HNURL}}" ADD_DATE="{{.Time.Unix}}">{{.Title}}</A>
+{{- if .URL}}
+    <DD>{{.URL}}
+{{- end}}
+{{- end}}
+</DL><p>
+`))
+
+type opml struct {
+	XMLName xml.Name  `xml:"opml"`
+	Version string    `xml:"version,attr"`
+	Title   string    `xml:"head>title"`
+	Created string    `xml:"head>dateCreated"`
+	Outline []outline `xml:"body>outline"`
+}
+
+type outline struct {
+	Text    string `xml:"text,attr"`
+	Type    string `xml:"type,attr"`
+	URL     string `xml:"url,attr"`
+	HTMLURL string `xml:"htmlUrl,attr,omitempty"`
+}
+
+func Formats() []string {
+	return []string{FormatJSON, FormatCSV, FormatHTML, FormatOPML}
+}
+
+func Export(w io.Writer, items []*item.Item, format string) error {
+	records := newRecords(items)
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(records); err != nil {
+			return fmt.Errorf("could not encode favorites: %w", err)
+		}
+
+		return nil
+
+	case FormatCSV:
+		return writeCSV(w, records)
+
+	case FormatHTML:
+		if err := bookmarks.Execute(w, records); err != nil {
+			return fmt.Errorf("could not write bookmarks: %w", err)
+		}
+
+		return nil
+
+	case FormatOPML:
+		return writeOPML(w, records)
+
+	default:
+		return fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats(), ", "))
+	}
+}
+
+func newRecords(items []*item.Item) []record {
+	records := make([]record, 0, len(items))
+
+	for _, it := range items {
+		records = append(records, record{
+			ID:       it.ID,
+			Title:    it.Title,
+			URL:      it.URL,
+			HNURL:    endpoints.HackerNewsURL + "/item?id=" + strconv.Itoa(it.ID),
+			Author:   it.User,
+			Points:   it.Points,
+			Comments: it.CommentsCount,
+			Time:     time.Unix(it.Time, 0).UTC(),
+		})
+	}
+
+	return records
+}
+
+func writeCSV(w io.Writer, records []record) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"id", "title", "url", "hn_url", "author", "points", "comments", "time"}}
+
+	for _, r := range records {
+		rows = append(rows, []string{strconv.Itoa(r.ID), r.Title, r.URL, r.HNURL, r.Author,
+			strconv.Itoa(r.Points), strconv.Itoa(r.Comments), r.Time.Format(time.RFC3339)})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("could not write favorites: %w", err)
+	}
+
+	return nil
+}
+
+func writeOPML(w io.Writer, records []record) error {
+	doc := opml{
+		Version: "2.0",
+		Title:   "circumflex favorites",
+		Created: time.Now().UTC().Format(time.RFC1123Z),
+	}
+
+	for _, r := range records {
+		doc.Outline = append(doc.Outline, outline{Text: r.Title, Type: "link", URL: r.HNURL, HTMLURL: r.URL})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("could not write favorites: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("could not encode favorites: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
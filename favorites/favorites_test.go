@@ -0,0 +1,81 @@
+package favorites_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"clx/favorites"
+	"clx/item"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+
+	fav := new(favorites.Favorites)
+
+	for _, id := range []int{1, 2, 3, 4} {
+		fav.Add(&item.Item{ID: id})
+	}
+
+	assert.NoError(t, fav.Move(0, 2))
+	assert.Equal(t, []int{2, 3, 1, 4}, ids(fav.GetItems()))
+
+	assert.NoError(t, fav.Move(3, 0))
+	assert.Equal(t, []int{4, 2, 3, 1}, ids(fav.GetItems()))
+
+	assert.Error(t, fav.Move(0, 4))
+	assert.Error(t, fav.Remove(4))
+	assert.Equal(t, 2, fav.Index(3))
+	assert.Equal(t, -1, fav.Index(5))
+}
+
+func TestExportedBookmarksCanBeImported(t *testing.T) {
+	t.Parallel()
+
+	items := []*item.Item{
+		{ID: 1, Title: "A <title> & more", URL: "https://example.com"},
+		{ID: 2, Title: "Ask HN: Text post"},
+	}
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, favorites.Export(&buf, items, favorites.FormatHTML))
+	assert.Contains(t, buf.String(), `<A HREF="https://news.ycombinator.com/item?id=1" ADD_DATE="0">`+
+		"A &lt;title&gt; &amp; more</A>")
+
+	ids, unrecognized, err := favorites.ParseIDs(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+	assert.Empty(t, unrecognized)
+}
+
+func TestParseList(t *testing.T) {
+	t.Parallel()
+
+	list := `# Comments are ignored
+34567
+
+https://news.ycombinator.com/item?id=12345
+https://example.com/article
+`
+
+	ids, unrecognized, err := favorites.ParseIDs(strings.NewReader(list))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{34567, 12345}, ids)
+	assert.Equal(t, []string{"https://example.com/article"}, unrecognized)
+}
+
+func ids(items []*item.Item) []int {
+	ids := make([]int, 0, len(items))
+
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+
+	return ids
+}
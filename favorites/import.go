@@ -0,0 +1,80 @@
+package favorites
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"clx/hn"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ParseIDs reads the IDs of Hacker News items from bookmark HTML or from a
+// list with one ID or URL per line. Entries that do not refer to an item
+// are returned separately.
+func ParseIDs(r io.Reader) ([]int, []string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read favorites: %w", err)
+	}
+
+	entries, err := getEntries(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		ids          []int
+		unrecognized []string
+	)
+
+	for _, entry := range entries {
+		id, err := hn.ParseID(entry)
+		if err != nil {
+			unrecognized = append(unrecognized, entry)
+
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, unrecognized, nil
+}
+
+func getEntries(data []byte) ([]string, error) {
+	var entries []string
+
+	if bytes.Contains(bytes.ToLower(data), []byte("<a ")) {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse bookmarks: %w", err)
+		}
+
+		doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+			entries = append(entries, s.AttrOr("href", ""))
+		})
+
+		return entries, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read favorites: %w", err)
+	}
+
+	return entries, nil
+}
@@ -9,6 +9,7 @@ import (
 const (
 	ConfigFileNameFull    = "config.env"
 	FavoritesFileNameFull = "favorites.json"
+	SessionFileNameFull   = "session"
 )
 
 func PathToConfigDirectory() string {
@@ -19,6 +20,14 @@ func PathToConfigDirectory() string {
 	return path.Join(homeDir, configDir, clxDir)
 }
 
+func PathToCacheDirectory() string {
+	homeDir, _ := os.UserHomeDir()
+	cacheDir := ".cache"
+	clxDir := "circumflex"
+
+	return path.Join(homeDir, cacheDir, clxDir)
+}
+
 func PathToConfigFile() string {
 	return path.Join(PathToConfigDirectory(), ConfigFileNameFull)
 }
@@ -27,6 +36,10 @@ func PathToFavoritesFile() string {
 	return path.Join(PathToConfigDirectory(), FavoritesFileNameFull)
 }
 
+func PathToSessionFile() string {
+	return path.Join(PathToConfigDirectory(), SessionFileNameFull)
+}
+
 func Exists(pathToFile string) bool {
 	if _, err := os.Stat(pathToFile); os.IsNotExist(err) {
 		return false
@@ -50,6 +63,8 @@ func WriteToFile(path string, content string) error {
 		return fmt.Errorf("could not create config file: %w", createPathErr)
 	}
 
+	defer file.Close()
+
 	_, writeFileErr := file.WriteString(content)
 	if writeFileErr != nil {
 		return fmt.Errorf("could not write to file: %w", writeFileErr)
@@ -71,6 +86,8 @@ func WriteToFileNew(dirPath string, fileName string, content string) error {
 		return fmt.Errorf("could not create config file: %w", createPathErr)
 	}
 
+	defer file.Close()
+
 	_, writeFileErr := file.WriteString(content)
 	if writeFileErr != nil {
 		return fmt.Errorf("could not write to file: %w", writeFileErr)
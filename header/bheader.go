@@ -1,6 +1,7 @@
 package header
 
 import (
+	"fmt"
 	"strings"
 
 	"clx/constants/category"
@@ -9,6 +10,56 @@ import (
 )
 
 func GetHeader(selectedSubHeader int, favoritesHasItems bool, width int) string {
+	title := getTitle()
+	categories := getCategories(selectedSubHeader, favoritesHasItems)
+	filler := getFiller(title, categories, width)
+
+	return title + categories + filler
+}
+
+// GetSearchHeader replaces the list of categories with the search query
+// while search results are shown.
+func GetSearchHeader(query string, width int) string {
+	bg := style.GetHeaderBg()
+
+	title := getTitle()
+	search := lipgloss.NewStyle().
+		Foreground(style.GetUnselectedItemFg()).
+		Background(bg).
+		Render("   search: ") +
+		lipgloss.NewStyle().
+			Background(bg).
+			Bold(true).
+			Render(query)
+	filler := getFiller(title, search, width)
+
+	return title + search + filler
+}
+
+// GetUserHeader replaces the list of categories with a summary of the user
+// while their profile is shown.
+func GetUserHeader(name string, karma int, joinedAgo string, width int) string {
+	bg := style.GetHeaderBg()
+
+	title := getTitle()
+	profile := lipgloss.NewStyle().
+		Foreground(style.GetUnselectedItemFg()).
+		Background(bg).
+		Render("   user: ") +
+		lipgloss.NewStyle().
+			Background(bg).
+			Bold(true).
+			Render(name) +
+		lipgloss.NewStyle().
+			Foreground(style.GetUnselectedItemFg()).
+			Background(bg).
+			Render(fmt.Sprintf(" • %d karma • joined %s", karma, joinedAgo))
+	filler := getFiller(title, profile, width)
+
+	return title + profile + filler
+}
+
+func getTitle() string {
 	bg := style.GetLogoBg()
 
 	c := lipgloss.NewStyle().
@@ -23,11 +74,7 @@ func GetHeader(selectedSubHeader int, favoritesHasItems bool, width int) string
 		Foreground(style.GetBlue()).
 		Background(bg)
 
-	title := c.Render("  c") + l.Render("l") + x.Render("x  ")
-	categories := getCategories(selectedSubHeader, favoritesHasItems)
-	filler := getFiller(title, categories, width)
-
-	return title + categories + filler
+	return c.Render("  c") + l.Render("l") + x.Render("x  ")
 }
 
 func getFiller(title string, categories string, width int) string {
@@ -78,10 +125,10 @@ func getCategories(selectedSubHeader int, favoritesHasItems bool) string {
 
 func getSubHeaders(favoritesHasItems bool) []string {
 	if favoritesHasItems {
-		return []string{"new", "ask", "show", "favorites"}
+		return []string{"new", "ask", "show", "best", "jobs", "launch", "favorites"}
 	}
 
-	return []string{"new", "ask", "show"}
+	return []string{"new", "ask", "show", "best", "jobs", "launch"}
 }
 
 func getColor(i int, selectedSubHeader int) (lipgloss.TerminalColor, bool) {
@@ -100,6 +147,12 @@ func getSelectedCategoryColor(selectedSubHeader int) (lipgloss.TerminalColor, bo
 		return style.GetYellow(), true
 	case category.Show:
 		return style.GetBlue(), true
+	case category.Best:
+		return style.GetOrange(), true
+	case category.Jobs:
+		return style.GetGreen(), true
+	case category.Launch:
+		return style.GetCyan(), true
 	case category.Favorites:
 		return style.GetPink(), true
 	default:
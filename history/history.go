@@ -4,26 +4,54 @@ import (
 	"encoding/json"
 	"os"
 	"path"
+	"time"
 
 	"clx/file"
+	"clx/item"
 )
 
 type History interface {
 	Contains(id int) bool
 	GetLastVisited(id int) int64
 	GetLastCommentCount(id int) int
+	GetVisitedStories() map[int]StoryInfo
 	ClearAndWriteToDisk()
-	MarkAsReadAndWriteToDisk(id int, commentsOnLastVisit int)
+	MarkAsReadAndWriteToDisk(story *item.Item, commentsOnLastVisit int)
+	// ForgetAndWriteToDisk removes the story and reports whether it was in the
+	// history
+	ForgetAndWriteToDisk(id int) bool
+	// PruneAndWriteToDisk removes stories last visited before the given time
+	// and returns how many were removed
+	PruneAndWriteToDisk(before time.Time) int
 }
 
+// New returns the history that matches the settings: a mock in debug mode, one
+// that forgets everything on exit if marking stories as read is disabled and
+// the history file otherwise.
+func New(debugMode bool, doNotMarkAsRead bool) History {
+	if debugMode {
+		return NewMockHistory()
+	}
+
+	if doNotMarkAsRead {
+		return NewNonPersistentHistory()
+	}
+
+	return NewPersistentHistory()
+}
+
+// NewPersistentHistory reads the history file. The file is only created once
+// a story is marked as read.
 func NewPersistentHistory() History {
-	h := &Persistent{VisitedStories: make(map[int]StoryInfo)}
+	return NewPersistentHistoryAt(PathToHistoryFile())
+}
 
-	fullPath, dirPath, fileName := getCacheFilePaths()
+// NewPersistentHistoryAt reads the history from the file at fullPath instead
+// of the default location.
+func NewPersistentHistoryAt(fullPath string) History {
+	h := &Persistent{VisitedStories: make(map[int]StoryInfo), path: fullPath}
 
 	if !exists(fullPath) {
-		writeToDisk(h, dirPath, fileName)
-
 		return h
 	}
 
@@ -58,6 +86,12 @@ func writeToDisk(h *Persistent, dirPath string, fileName string) {
 	}
 }
 
+func PathToHistoryFile() string {
+	fullPath, _, _ := getCacheFilePaths()
+
+	return fullPath
+}
+
 func getCacheFilePaths() (string, string, string) {
 	homeDir, _ := os.UserHomeDir()
 	configDir := ".cache"
@@ -1,6 +1,10 @@
 package history
 
-import "time"
+import (
+	"time"
+
+	"clx/item"
+)
 
 type Mock struct{}
 
@@ -34,4 +38,16 @@ func (Mock) GetLastCommentCount(_ int) int {
 
 func (Mock) ClearAndWriteToDisk() {}
 
-func (Mock) MarkAsReadAndWriteToDisk(_ int, _ int) {}
+func (Mock) GetVisitedStories() map[int]StoryInfo {
+	return nil
+}
+
+func (Mock) MarkAsReadAndWriteToDisk(_ *item.Item, _ int) {}
+
+func (Mock) ForgetAndWriteToDisk(_ int) bool {
+	return false
+}
+
+func (Mock) PruneAndWriteToDisk(_ time.Time) int {
+	return 0
+}
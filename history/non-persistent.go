@@ -1,6 +1,10 @@
 package history
 
-import "time"
+import (
+	"time"
+
+	"clx/item"
+)
 
 type NonPersistent struct{}
 
@@ -18,4 +22,16 @@ func (NonPersistent) GetLastCommentCount(_ int) int {
 
 func (NonPersistent) ClearAndWriteToDisk() {}
 
-func (NonPersistent) MarkAsReadAndWriteToDisk(_ int, _ int) {}
+func (NonPersistent) GetVisitedStories() map[int]StoryInfo {
+	return nil
+}
+
+func (NonPersistent) MarkAsReadAndWriteToDisk(_ *item.Item, _ int) {}
+
+func (NonPersistent) ForgetAndWriteToDisk(_ int) bool {
+	return false
+}
+
+func (NonPersistent) PruneAndWriteToDisk(_ time.Time) int {
+	return 0
+}
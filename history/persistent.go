@@ -3,16 +3,28 @@ package history
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"time"
+
+	"clx/item"
 )
 
 type Persistent struct {
 	VisitedStories map[int]StoryInfo
+
+	// path defaults to ~/.cache/circumflex/history.json if left empty
+	path string
 }
 
+// maxVisits is the number of visits that are kept per story for the stats
+const maxVisits = 50
+
 type StoryInfo struct {
 	LastVisited         int64
 	CommentsOnLastVisit int
+	Title               string  `json:",omitempty"`
+	Domain              string  `json:",omitempty"`
+	Visits              []int64 `json:",omitempty"`
 }
 
 func (his *Persistent) Contains(id int) bool {
@@ -37,21 +49,71 @@ func (his *Persistent) GetLastCommentCount(id int) int {
 	return 0
 }
 
+func (his *Persistent) GetVisitedStories() map[int]StoryInfo {
+	return his.VisitedStories
+}
+
 func (his *Persistent) ClearAndWriteToDisk() {
 	his.VisitedStories = make(map[int]StoryInfo)
 
-	_, dirPath, fileName := getCacheFilePaths()
-	writeToDisk(his, dirPath, fileName)
+	his.writeToDisk()
+}
+
+func (his *Persistent) MarkAsReadAndWriteToDisk(story *item.Item, commentsOnLastVisit int) {
+	now := time.Now().Unix()
+
+	info := his.VisitedStories[story.ID]
+	info.LastVisited = now
+	info.CommentsOnLastVisit = commentsOnLastVisit
+	info.Title = story.Title
+	info.Domain = story.Domain
+	info.Visits = append(info.Visits, now)
+
+	if len(info.Visits) > maxVisits {
+		info.Visits = info.Visits[len(info.Visits)-maxVisits:]
+	}
+
+	his.VisitedStories[story.ID] = info
+
+	his.writeToDisk()
+}
+
+func (his *Persistent) ForgetAndWriteToDisk(id int) bool {
+	if !his.Contains(id) {
+		return false
+	}
+
+	delete(his.VisitedStories, id)
+
+	his.writeToDisk()
+
+	return true
+}
+
+func (his *Persistent) PruneAndWriteToDisk(before time.Time) int {
+	pruned := 0
+
+	for id, info := range his.VisitedStories {
+		if info.LastVisited < before.Unix() {
+			delete(his.VisitedStories, id)
+			pruned++
+		}
+	}
+
+	if pruned > 0 {
+		his.writeToDisk()
+	}
+
+	return pruned
 }
 
-func (his *Persistent) MarkAsReadAndWriteToDisk(id int, commentsOnLastVisit int) {
-	his.VisitedStories[id] = StoryInfo{
-		LastVisited:         time.Now().Unix(),
-		CommentsOnLastVisit: commentsOnLastVisit,
+func (his *Persistent) writeToDisk() {
+	fullPath := his.path
+	if fullPath == "" {
+		fullPath = PathToHistoryFile()
 	}
 
-	_, dirPath, fileName := getCacheFilePaths()
-	writeToDisk(his, dirPath, fileName)
+	writeToDisk(his, filepath.Dir(fullPath), filepath.Base(fullPath))
 }
 
 func Initialize(isEnabled bool) *Persistent {
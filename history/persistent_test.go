@@ -0,0 +1,63 @@
+package history_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"clx/history"
+	"clx/item"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForgetAndWriteToDisk(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	his := history.NewPersistentHistoryAt(path)
+	his.MarkAsReadAndWriteToDisk(&item.Item{ID: 1, Title: "One"}, 10)
+	his.MarkAsReadAndWriteToDisk(&item.Item{ID: 2, Title: "Two"}, 20)
+
+	assert.True(t, his.ForgetAndWriteToDisk(1))
+	assert.False(t, his.ForgetAndWriteToDisk(3))
+
+	reloaded := history.NewPersistentHistoryAt(path)
+
+	assert.False(t, reloaded.Contains(1))
+	assert.True(t, reloaded.Contains(2))
+	assert.Equal(t, 20, reloaded.GetLastCommentCount(2))
+}
+
+func TestPruneAndWriteToDisk(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	now := time.Now()
+	content := fmt.Sprintf(`{"1":{"LastVisited":%d},"2":{"LastVisited":%d}}`, now.AddDate(0, 0, -40).Unix(),
+		now.AddDate(0, 0, -10).Unix())
+
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	his := history.NewPersistentHistoryAt(path)
+
+	assert.Equal(t, 1, his.PruneAndWriteToDisk(now.AddDate(0, 0, -30)))
+	assert.Equal(t, 0, his.PruneAndWriteToDisk(now.AddDate(0, 0, -30)))
+
+	reloaded := history.NewPersistentHistoryAt(path)
+
+	assert.False(t, reloaded.Contains(1))
+	assert.True(t, reloaded.Contains(2))
+}
+
+func TestReadingDoesNotCreateTheFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	assert.Empty(t, history.NewPersistentHistoryAt(path).GetVisitedStories())
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,115 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+type Stats struct {
+	Stories      int
+	Visits       int
+	VisitsPerDay []DayCount
+	Domains      []DomainCount
+	Revisited    []Revisit
+}
+
+type DayCount struct {
+	Day    time.Time
+	Visits int
+}
+
+type DomainCount struct {
+	Domain  string
+	Stories int
+}
+
+type Revisit struct {
+	ID     int
+	Title  string
+	Visits int
+}
+
+// NewStats summarizes the history over the given number of days up to now.
+// Stories that were read before visits were recorded count as one visit at
+// the time of the last visit.
+func NewStats(stories map[int]StoryInfo, now time.Time, days int) Stats {
+	stats := Stats{Stories: len(stories)}
+
+	today := startOfDay(now)
+	first := today.AddDate(0, 0, -(days - 1))
+
+	for i := 0; i < days; i++ {
+		stats.VisitsPerDay = append(stats.VisitsPerDay, DayCount{Day: first.AddDate(0, 0, i)})
+	}
+
+	domains := make(map[string]int)
+
+	for id, info := range stories {
+		visits := info.Visits
+		if len(visits) == 0 {
+			visits = []int64{info.LastVisited}
+		}
+
+		stats.Visits += len(visits)
+
+		for _, visit := range visits {
+			day := startOfDay(time.Unix(visit, 0).In(now.Location()))
+
+			if index := dayIndex(first, day); index >= 0 && index < days {
+				stats.VisitsPerDay[index].Visits++
+			}
+		}
+
+		if info.Domain != "" {
+			domains[info.Domain]++
+		}
+
+		if len(visits) > 1 {
+			stats.Revisited = append(stats.Revisited, Revisit{ID: id, Title: info.Title, Visits: len(visits)})
+		}
+	}
+
+	for domain, count := range domains {
+		stats.Domains = append(stats.Domains, DomainCount{Domain: domain, Stories: count})
+	}
+
+	sort.Slice(stats.Domains, func(i, j int) bool {
+		if stats.Domains[i].Stories != stats.Domains[j].Stories {
+			return stats.Domains[i].Stories > stats.Domains[j].Stories
+		}
+
+		return stats.Domains[i].Domain < stats.Domains[j].Domain
+	})
+
+	sort.Slice(stats.Revisited, func(i, j int) bool {
+		if stats.Revisited[i].Visits != stats.Revisited[j].Visits {
+			return stats.Revisited[i].Visits > stats.Revisited[j].Visits
+		}
+
+		return stats.Revisited[i].ID > stats.Revisited[j].ID
+	})
+
+	return stats
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// dayIndex counts calendar days rather than 24 hour periods so that changes
+// to daylight saving time do not shift the days.
+func dayIndex(first time.Time, day time.Time) int {
+	index := 0
+
+	for d := first; d.Before(day); d = d.AddDate(0, 0, 1) {
+		index++
+	}
+
+	if day.Before(first) {
+		return -1
+	}
+
+	return index
+}
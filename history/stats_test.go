@@ -0,0 +1,40 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"clx/history"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.March, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1).Unix()
+	lastMonth := now.AddDate(0, -1, 0).Unix()
+
+	stories := map[int]history.StoryInfo{
+		1: {LastVisited: now.Unix(), Domain: "github.com", Title: "One",
+			Visits: []int64{lastMonth, yesterday, now.Unix()}},
+		2: {LastVisited: yesterday, Domain: "github.com", Visits: []int64{yesterday}},
+		3: {LastVisited: yesterday, Domain: "example.com", Visits: []int64{yesterday}},
+		// Read before visits were recorded
+		4: {LastVisited: now.Unix()},
+	}
+
+	stats := history.NewStats(stories, now, 3)
+
+	assert.Equal(t, 4, stats.Stories)
+	assert.Equal(t, 6, stats.Visits)
+	assert.Equal(t, []history.DayCount{
+		{Day: time.Date(2022, time.March, 8, 0, 0, 0, 0, time.UTC), Visits: 0},
+		{Day: time.Date(2022, time.March, 9, 0, 0, 0, 0, time.UTC), Visits: 3},
+		{Day: time.Date(2022, time.March, 10, 0, 0, 0, 0, time.UTC), Visits: 2},
+	}, stats.VisitsPerDay)
+	assert.Equal(t, []history.DomainCount{{Domain: "github.com", Stories: 2}, {Domain: "example.com", Stories: 1}},
+		stats.Domains)
+	assert.Equal(t, []history.Revisit{{ID: 1, Title: "One", Visits: 3}}, stats.Revisited)
+}
@@ -0,0 +1,87 @@
+package hn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+var (
+	ErrTimeout     = errors.New("request timed out")
+	ErrNotFound    = errors.New("item not found")
+	ErrRateLimited = errors.New("rate limited by server")
+
+	ErrUnsupportedCategory = errors.New("category not supported by this backend")
+	ErrNotSynced           = errors.New("not part of the offline snapshot")
+)
+
+// ClassifyError maps transport errors and HTTP status codes onto the typed
+// errors above so that callers can use errors.Is regardless of the service.
+func ClassifyError(statusCode int, err error) error {
+	if err != nil {
+		var netErr net.Error
+
+		switch {
+		case errors.Is(err, context.Canceled):
+			return context.Canceled
+
+		case errors.Is(err, context.DeadlineExceeded):
+			return fmt.Errorf("%w: %v", ErrTimeout, err)
+
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return fmt.Errorf("%w: %v", ErrTimeout, err)
+
+		default:
+			return err
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return ErrTimeout
+
+	case statusCode >= http.StatusBadRequest:
+		return fmt.Errorf("unexpected status code %d", statusCode)
+
+	default:
+		return nil
+	}
+}
+
+// ErrorMessage returns a short, human-readable description of err suitable
+// for the status bar.
+func ErrorMessage(err error) string {
+	switch {
+	case err == nil:
+		return ""
+
+	case errors.Is(err, context.Canceled):
+		return "Fetching cancelled"
+
+	case errors.Is(err, ErrTimeout):
+		return "Request timed out"
+
+	case errors.Is(err, ErrNotFound):
+		return "Item not found"
+
+	case errors.Is(err, ErrRateLimited):
+		return "Rate limited, try again later"
+
+	case errors.Is(err, ErrUnsupportedCategory):
+		return "Category not supported by this backend"
+
+	case errors.Is(err, ErrNotSynced):
+		return "Not available offline, run clx sync to download it"
+
+	default:
+		return err.Error()
+	}
+}
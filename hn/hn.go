@@ -1,9 +1,40 @@
 package hn
 
-import "clx/item"
+import (
+	"context"
+
+	"clx/item"
+	"clx/user"
+)
 
 type Service interface {
-	FetchItems(itemsToFetch int, category int) (items []*item.Item, errMsg string)
-	FetchItem(id int) *item.Item
-	FetchComments(int) *item.Item
+	// FetchItems returns up to itemsToFetch items of the category, starting at
+	// the given offset into its ranking
+	FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error)
+	FetchItem(ctx context.Context, id int) (*item.Item, error)
+	FetchComments(ctx context.Context, id int) (*item.Item, error)
+	FetchUser(ctx context.Context, name string) (*user.User, error)
+}
+
+type refreshKey struct{}
+
+// WithRefresh marks requests made with the returned context as started by
+// the user, so that services fetch fresh data instead of using stored data.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+// IsRefresh reports whether ctx has been returned by WithRefresh.
+func IsRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+
+	return refresh
+}
+
+// OfflineReporter is implemented by services that fall back to previously
+// stored data when the network can't be reached.
+type OfflineReporter interface {
+	// IsOffline reports whether the last response was served from storage
+	// because the network could not be reached
+	IsOffline() bool
 }
@@ -0,0 +1,73 @@
+package hn
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseID reads the ID of an item from a plain ID, a Hacker News item URL or
+// an Algolia URL. Links to a comment on the page of its story, such as
+// item?id=123#456, return the ID of the comment.
+func ParseID(value string) (int, error) {
+	value = strings.TrimSpace(value)
+
+	if id, err := strconv.Atoi(value); err == nil {
+		return validateID(id, value)
+	}
+
+	rawURL := value
+	if !strings.Contains(rawURL, "://") {
+		// Links are often copied without the scheme
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return 0, fmt.Errorf("'%s' is not an ID or a link to a Hacker News item", value)
+	}
+
+	id := getID(u)
+	if id == "" {
+		return 0, fmt.Errorf("'%s' is not an ID or a link to a Hacker News item", value)
+	}
+
+	// Comments are linked to by their ID on the page of the story
+	if _, err := strconv.Atoi(u.Fragment); err == nil {
+		id = u.Fragment
+	}
+
+	parsed, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not an ID or a link to a Hacker News item", value)
+	}
+
+	return validateID(parsed, value)
+}
+
+// getID returns the ID in item URLs on Hacker News, /api/v1/items/123 on the
+// Algolia API and ?story=123 on the Algolia search page.
+func getID(u *url.URL) string {
+	if strings.HasSuffix(u.Path, "/item") {
+		return u.Query().Get("id")
+	}
+
+	if _, id, found := strings.Cut(u.Path, "/api/v1/items/"); found {
+		return strings.TrimSuffix(id, "/")
+	}
+
+	if strings.HasSuffix(u.Hostname(), "algolia.com") {
+		return u.Query().Get("story")
+	}
+
+	return ""
+}
+
+func validateID(id int, value string) (int, error) {
+	if id <= 0 {
+		return 0, fmt.Errorf("'%s' is not a valid ID", value)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,37 @@
+package hn_test
+
+import (
+	"testing"
+
+	"clx/hn"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		"123":   123,
+		" 123 ": 123,
+		"https://news.ycombinator.com/item?id=123":     123,
+		"https://news.ycombinator.com/item?id=123#456": 456,
+		"news.ycombinator.com/item?id=123":             123,
+		"https://hn.algolia.com/api/v1/items/123":      123,
+		"https://hn.algolia.com/?query=go&story=123":   123,
+	}
+
+	for value, expected := range tests {
+		id, err := hn.ParseID(value)
+
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, id, value)
+	}
+
+	for _, value := range []string{"", "0", "-1", "abc", "https://example.com/article#2",
+		"https://news.ycombinator.com/news", "https://news.ycombinator.com/item?id=abc"} {
+		_, err := hn.ParseID(value)
+
+		assert.Error(t, err, value)
+	}
+}
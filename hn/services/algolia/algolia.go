@@ -0,0 +1,256 @@
+package algolia
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cat "clx/constants/category"
+	"clx/endpoints"
+	"clx/hn"
+	"clx/hn/services/internal/mapping"
+	"clx/item"
+	"clx/user"
+	"clx/utils/http"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+type Service struct {
+	// BaseURL defaults to endpoints.AlgoliaURL if left empty
+	BaseURL string
+}
+
+func (s *Service) baseURL() string {
+	if s.BaseURL == "" {
+		return endpoints.AlgoliaURL
+	}
+
+	return strings.TrimSuffix(s.BaseURL, "/")
+}
+
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	query, err := getCategory(category, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s&hitsPerPage=%d", s.baseURL(), query, itemsToFetch)
+	if offset > 0 {
+		url += fmt.Sprintf("&offset=%d&length=%d", offset, itemsToFetch)
+	}
+
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, url, a); err != nil {
+		return nil, fmt.Errorf("could not fetch stories: %w", err)
+	}
+
+	now := time.Now()
+	items := make([]*item.Item, 0, len(a.Hits))
+
+	for i := range a.Hits {
+		// The title search also matches submissions that merely mention
+		// Launch HN
+		if category == cat.Launch && !strings.HasPrefix(a.Hits[i].Title, "Launch HN") {
+			continue
+		}
+
+		items = append(items, mapHit(&a.Hits[i], now))
+	}
+
+	return items, nil
+}
+
+func mapHit(hit *endpoints.AlgoliaHit, now time.Time) *item.Item {
+	id, _ := strconv.Atoi(hit.ObjectID)
+
+	return &item.Item{
+		ID:            id,
+		Title:         mapping.Sanitize(hit.Title),
+		Points:        hit.Points,
+		User:          hit.Author,
+		Time:          int64(hit.CreatedAtI),
+		TimeAgo:       mapping.TimeAgo(int64(hit.CreatedAtI), now),
+		Type:          mapping.GetType(hit.Tags),
+		URL:           hit.URL,
+		Domain:        domainutil.Domain(hit.URL),
+		CommentsCount: hit.NumComments,
+	}
+}
+
+// getCategory returns the search endpoint and query for a category. Algolia
+// only mirrors the ranking of the front page, so the remaining categories are
+// approximated by sorting their submissions by date, or by points in the
+// case of Best.
+func getCategory(category int, now time.Time) (string, error) {
+	switch category {
+	case cat.FrontPage:
+		return "search?tags=front_page", nil
+
+	case cat.New:
+		return "search_by_date?tags=story", nil
+
+	case cat.Ask:
+		return "search_by_date?tags=ask_hn", nil
+
+	case cat.Show:
+		return "search_by_date?tags=show_hn", nil
+
+	case cat.Best:
+		lastWeek := now.Add(-7 * 24 * time.Hour).Unix()
+
+		return fmt.Sprintf("search?tags=story&numericFilters=created_at_i>%d", lastWeek), nil
+
+	case cat.Jobs:
+		return "search_by_date?tags=job", nil
+
+	case cat.Launch:
+		return "search_by_date?tags=story&restrictSearchableAttributes=title&query=%22Launch%20HN%22", nil
+
+	default:
+		return "", fmt.Errorf("%w: %d", hn.ErrUnsupportedCategory, category)
+	}
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.fetchItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, err)
+	}
+
+	it := mapItem(story, 0, time.Now())
+	it.CommentsCount = countComments(story.Children)
+	it.PollOptions = mapPollOptions(story.Options)
+
+	return it, nil
+}
+
+// FetchComments uses the items endpoint, which returns the complete nested
+// comment tree in a single request.
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.fetchItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	now := time.Now()
+
+	it := mapItem(story, 0, now)
+	it.Comments = mapComments(story.Children, 0, now)
+	it.CommentsCount = countComments(story.Children)
+	it.PollOptions = mapPollOptions(story.Options)
+
+	return it, nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*user.User, error) {
+	u := new(endpoints.AlgoliaUser)
+	url := fmt.Sprintf("%s/users/%s", s.baseURL(), name)
+
+	if err := http.Get(ctx, url, u); err != nil {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, err)
+	}
+
+	if u.Username == "" {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, hn.ErrNotFound)
+	}
+
+	return &user.User{
+		Name:    u.Username,
+		Created: int64(u.CreatedAtI),
+		Karma:   u.Karma,
+		About:   u.About,
+	}, nil
+}
+
+func (s *Service) fetchItem(ctx context.Context, id int) (*endpoints.AlgoliaItem, error) {
+	story := new(endpoints.AlgoliaItem)
+	url := fmt.Sprintf("%s/items/%d", s.baseURL(), id)
+
+	if err := http.Get(ctx, url, story); err != nil {
+		return nil, err
+	}
+
+	if story.ID == 0 {
+		return nil, hn.ErrNotFound
+	}
+
+	return story, nil
+}
+
+func mapComments(children []endpoints.AlgoliaItem, level int, now time.Time) []*item.Item {
+	items := make([]*item.Item, 0, len(children))
+
+	for i := range children {
+		it := mapItem(&children[i], level, now)
+		it.Comments = mapComments(children[i].Children, level+1, now)
+
+		items = append(items, it)
+	}
+
+	return items
+}
+
+func mapItem(story *endpoints.AlgoliaItem, level int, now time.Time) *item.Item {
+	content := story.Text
+	if content != "" && !strings.HasPrefix(content, "<p>") {
+		// The comment parser expects every paragraph, including the first
+		// one, to be prefixed with <p>
+		content = "<p>" + content
+	}
+
+	// Deleted comments are kept by Algolia, but without an author or text
+	if story.Type == "comment" && story.Author == "" {
+		content = "[deleted]"
+	}
+
+	return &item.Item{
+		ID:      story.ID,
+		Parent:  story.ParentID,
+		Title:   mapping.Sanitize(story.Title),
+		Points:  story.Points,
+		User:    story.Author,
+		Time:    int64(story.CreatedAtI),
+		TimeAgo: mapping.TimeAgo(int64(story.CreatedAtI), now),
+		Type:    story.Type,
+		URL:     story.URL,
+		Level:   level,
+		Domain:  domainutil.Domain(story.URL),
+		Content: content,
+	}
+}
+
+func mapPollOptions(options []endpoints.AlgoliaItem) []*item.PollOption {
+	if len(options) == 0 {
+		return nil
+	}
+
+	pollOptions := make([]*item.PollOption, 0, len(options))
+
+	for i := range options {
+		pollOptions = append(pollOptions, &item.PollOption{
+			ID:     options[i].ID,
+			Text:   options[i].Text,
+			Points: options[i].Points,
+		})
+	}
+
+	return pollOptions
+}
+
+func countComments(children []endpoints.AlgoliaItem) int {
+	count := 0
+
+	for i := range children {
+		if children[i].Author != "" {
+			count++
+		}
+
+		count += countComments(children[i].Children)
+	}
+
+	return count
+}
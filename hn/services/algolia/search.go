@@ -0,0 +1,176 @@
+package algolia
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/endpoints"
+	"clx/hn/services/internal/mapping"
+	"clx/item"
+	"clx/utils/http"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+type Scope int
+
+const (
+	ScopeStories Scope = iota
+	ScopeComments
+	ScopeAll
+)
+
+type SearchOptions struct {
+	Query string
+	Scope Scope
+
+	// SortByDate lists the most recent hits first instead of the most
+	// relevant ones
+	SortByDate bool
+
+	// Since and Until restrict the hits to a date range. Zero values leave
+	// the range open.
+	Since time.Time
+	Until time.Time
+
+	Author      string
+	MinPoints   int
+	MinComments int
+
+	// Domain is matched against the domain of the linked article after the
+	// hits have been fetched, since Algolia can't filter on it
+	Domain string
+}
+
+// SearchResult is one page of hits together with the number of pages that
+// Algolia has for the query.
+type SearchResult struct {
+	Items []*item.Item
+
+	// ObjectIDs holds the ID of each hit. It differs from the ID of the item
+	// for comment hits, which are mapped to the story they were posted on.
+	ObjectIDs []int
+
+	Page    int
+	NbPages int
+}
+
+// Search runs a full-text query. Comment hits are mapped to the story they
+// were posted on so that they can be opened like any other story.
+func (s *Service) Search(ctx context.Context, options SearchOptions, page int, hitsPerPage int) (*SearchResult, error) {
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, s.searchURL(options, page, hitsPerPage), a); err != nil {
+		return nil, fmt.Errorf("could not search for '%s': %w", options.Query, err)
+	}
+
+	now := time.Now()
+	result := &SearchResult{Page: a.Page, NbPages: a.NbPages}
+
+	for i := range a.Hits {
+		it := mapHit(&a.Hits[i], now)
+		if isComment(a.Hits[i].Tags) {
+			it = mapCommentHit(&a.Hits[i], now)
+		}
+
+		if options.Domain != "" && !strings.EqualFold(it.Domain, options.Domain) {
+			continue
+		}
+
+		objectID, _ := strconv.Atoi(a.Hits[i].ObjectID)
+
+		result.Items = append(result.Items, it)
+		result.ObjectIDs = append(result.ObjectIDs, objectID)
+	}
+
+	return result, nil
+}
+
+func (s *Service) searchURL(options SearchOptions, page int, hitsPerPage int) string {
+	endpoint := "search"
+	if options.SortByDate {
+		endpoint = "search_by_date"
+	}
+
+	tags := map[Scope]string{
+		ScopeStories:  "story",
+		ScopeComments: "comment",
+		ScopeAll:      "(story,comment)",
+	}[options.Scope]
+
+	if options.Author != "" {
+		tags += ",author_" + options.Author
+	}
+
+	params := url.Values{}
+	params.Set("query", options.Query)
+	params.Set("tags", tags)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("hitsPerPage", strconv.Itoa(hitsPerPage))
+
+	var filters []string
+
+	if !options.Since.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i>%d", options.Since.Unix()))
+	}
+
+	if !options.Until.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i<%d", options.Until.Unix()))
+	}
+
+	if options.MinPoints > 0 {
+		filters = append(filters, fmt.Sprintf("points>=%d", options.MinPoints))
+	}
+
+	if options.MinComments > 0 {
+		filters = append(filters, fmt.Sprintf("num_comments>=%d", options.MinComments))
+	}
+
+	if len(filters) > 0 {
+		params.Set("numericFilters", strings.Join(filters, ","))
+	}
+
+	return fmt.Sprintf("%s/%s?%s", s.baseURL(), endpoint, params.Encode())
+}
+
+func mapCommentHit(hit *endpoints.AlgoliaHit, now time.Time) *item.Item {
+	storyURL := toString(hit.StoryURL)
+
+	return &item.Item{
+		ID:      toInt(hit.StoryID),
+		Title:   mapping.Sanitize(toString(hit.StoryTitle)),
+		User:    hit.Author,
+		Time:    int64(hit.CreatedAtI),
+		TimeAgo: mapping.TimeAgo(int64(hit.CreatedAtI), now),
+		Type:    "comment",
+		URL:     storyURL,
+		Domain:  domainutil.Domain(storyURL),
+		Content: toString(hit.CommentText),
+	}
+}
+
+func isComment(tags []string) bool {
+	for _, tag := range tags {
+		if tag == "comment" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+
+	return s
+}
+
+func toInt(v interface{}) int {
+	f, _ := v.(float64)
+
+	return int(f)
+}
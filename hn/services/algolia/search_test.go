@@ -0,0 +1,87 @@
+package algolia_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"clx/hn/services/algolia"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchComments(t *testing.T) {
+	t.Parallel()
+
+	var request *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+
+		_, _ = w.Write([]byte(`{"hits":[{"objectID":"20","author":"alfa","comment_text":"Comment",` +
+			`"story_id":10,"story_title":"Story","story_url":"https://example.com/post","created_at_i":1643215106,` +
+			`"_tags":["comment","story_10"]}],"page":1,"nbPages":4}`))
+	}))
+	defer server.Close()
+
+	service := &algolia.Service{BaseURL: server.URL}
+	options := algolia.SearchOptions{
+		Query:      "go generics",
+		Scope:      algolia.ScopeComments,
+		SortByDate: true,
+		Since:      time.Unix(1000, 0),
+	}
+
+	result, err := service.Search(context.Background(), options, 1, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/search_by_date", request.URL.Path)
+	assert.Equal(t, "go generics", request.URL.Query().Get("query"))
+	assert.Equal(t, "comment", request.URL.Query().Get("tags"))
+	assert.Equal(t, "1", request.URL.Query().Get("page"))
+	assert.Equal(t, "created_at_i>1000", request.URL.Query().Get("numericFilters"))
+
+	assert.Equal(t, 4, result.NbPages)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, 10, result.Items[0].ID)
+	assert.Equal(t, "Story", result.Items[0].Title)
+	assert.Equal(t, "example.com", result.Items[0].Domain)
+	assert.Equal(t, "alfa", result.Items[0].User)
+}
+
+func TestSearchFilters(t *testing.T) {
+	t.Parallel()
+
+	var request *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+
+		_, _ = w.Write([]byte(`{"hits":[` +
+			`{"objectID":"1","title":"First","url":"https://example.com/a","author":"alfa","_tags":["story"]},` +
+			`{"objectID":"2","title":"Second","url":"https://other.org/b","author":"alfa","_tags":["story"]}` +
+			`],"page":0,"nbPages":1}`))
+	}))
+	defer server.Close()
+
+	service := &algolia.Service{BaseURL: server.URL}
+	options := algolia.SearchOptions{
+		Author:      "alfa",
+		MinPoints:   100,
+		MinComments: 10,
+		Domain:      "example.com",
+	}
+
+	result, err := service.Search(context.Background(), options, 0, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/search", request.URL.Path)
+	assert.Equal(t, "story,author_alfa", request.URL.Query().Get("tags"))
+	assert.Equal(t, "points>=100,num_comments>=10", request.URL.Query().Get("numericFilters"))
+
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, []int{1}, result.ObjectIDs)
+	assert.Equal(t, "First", result.Items[0].Title)
+}
@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"clx/constants/category"
+	"clx/file"
+	"clx/hn"
+	"clx/item"
+	"clx/user"
+)
+
+const (
+	defaultCategoryTTL = 5 * time.Minute
+	commentsTTL        = 2 * time.Minute
+	itemTTL            = time.Hour
+	userTTL            = time.Hour
+
+	// Entries that have expired less than staleWindow ago are returned right
+	// away while a fresh copy is fetched in the background
+	staleWindow = 30 * time.Minute
+
+	revalidationTimeout = 30 * time.Second
+)
+
+var categoryTTLs = map[int]time.Duration{
+	category.FrontPage: 5 * time.Minute,
+	category.New:       time.Minute,
+	category.Ask:       10 * time.Minute,
+	category.Show:      10 * time.Minute,
+	category.Best:      10 * time.Minute,
+	category.Jobs:      30 * time.Minute,
+	category.Launch:    30 * time.Minute,
+}
+
+// Service wraps another service and stores its responses in Directory.
+type Service struct {
+	Service   hn.Service
+	Directory string
+
+	offline      atomic.Bool
+	mu           sync.Mutex
+	revalidating map[string]bool
+}
+
+type entry struct {
+	FetchedAt int64
+	Requested int          `json:",omitempty"`
+	Items     []*item.Item `json:",omitempty"`
+	Item      *item.Item   `json:",omitempty"`
+	User      *user.User   `json:",omitempty"`
+}
+
+func (s *Service) IsOffline() bool {
+	return s.offline.Load()
+}
+
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	fetch := func(ctx context.Context) (*entry, error) {
+		items, err := s.Service.FetchItems(ctx, offset, itemsToFetch, category)
+
+		return &entry{Requested: itemsToFetch, Items: items}, err
+	}
+
+	// A list that was stored for a smaller request can't answer this one
+	isUsable := func(e *entry) bool {
+		return e.Requested >= itemsToFetch
+	}
+
+	e, err := s.get(ctx, categoryFileName(category, offset), getCategoryTTL(category), isUsable, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Items[0:min(itemsToFetch, len(e.Items))], nil
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	fetch := func(ctx context.Context) (*entry, error) {
+		story, err := s.Service.FetchItem(ctx, id)
+
+		return &entry{Item: story}, err
+	}
+
+	e, err := s.get(ctx, fmt.Sprintf("item-%d.json", id), itemTTL, isAlwaysUsable, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Item, nil
+}
+
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	fetch := func(ctx context.Context) (*entry, error) {
+		story, err := s.Service.FetchComments(ctx, id)
+
+		return &entry{Item: story}, err
+	}
+
+	e, err := s.get(ctx, fmt.Sprintf("comments-%d.json", id), commentsTTL, isAlwaysUsable, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Item, nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*user.User, error) {
+	fetch := func(ctx context.Context) (*entry, error) {
+		u, err := s.Service.FetchUser(ctx, name)
+
+		return &entry{User: u}, err
+	}
+
+	e, err := s.get(ctx, fmt.Sprintf("user-%s.json", name), userTTL, isAlwaysUsable, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.User, nil
+}
+
+func (s *Service) get(ctx context.Context, fileName string, ttl time.Duration, isUsable func(e *entry) bool,
+	fetch func(ctx context.Context) (*entry, error),
+) (*entry, error) {
+	cached := s.read(fileName)
+
+	// Refreshes started by the user skip fresh entries, but still fall back
+	// to them when the network can't be reached
+	if cached != nil && isUsable(cached) && !hn.IsRefresh(ctx) {
+		age := time.Since(time.Unix(cached.FetchedAt, 0))
+
+		if age < ttl {
+			s.offline.Store(false)
+
+			return cached, nil
+		}
+
+		if age < ttl+staleWindow {
+			s.revalidate(fileName, fetch)
+			s.offline.Store(false)
+
+			return cached, nil
+		}
+	}
+
+	fresh, err := fetch(ctx)
+	if err == nil {
+		s.write(fileName, fresh)
+		s.offline.Store(false)
+
+		return fresh, nil
+	}
+
+	if cached != nil && isNetworkError(err) {
+		s.offline.Store(true)
+
+		return cached, nil
+	}
+
+	return nil, err
+}
+
+// revalidate fetches a fresh copy of the entry in the background. The
+// request is detached from the caller's context since the caller has
+// already been served.
+func (s *Service) revalidate(fileName string, fetch func(ctx context.Context) (*entry, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.revalidating == nil {
+		s.revalidating = make(map[string]bool)
+	}
+
+	if s.revalidating[fileName] {
+		return
+	}
+
+	s.revalidating[fileName] = true
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), revalidationTimeout)
+		defer cancel()
+
+		if fresh, err := fetch(ctx); err == nil {
+			s.write(fileName, fresh)
+		}
+
+		s.mu.Lock()
+		delete(s.revalidating, fileName)
+		s.mu.Unlock()
+	}()
+}
+
+func (s *Service) read(fileName string) *entry {
+	content, err := os.ReadFile(filepath.Join(s.Directory, fileName))
+	if err != nil {
+		return nil
+	}
+
+	e := new(entry)
+	if err := json.Unmarshal(content, e); err != nil {
+		return nil
+	}
+
+	return e
+}
+
+// write stores the entry on disk. Failing to write to the cache is not
+// worth interrupting the user for, so errors are ignored.
+func (s *Service) write(fileName string, e *entry) {
+	e.FetchedAt = time.Now().Unix()
+
+	content, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = file.WriteToFileNew(s.Directory, fileName, string(content))
+}
+
+func categoryFileName(category int, offset int) string {
+	if offset == 0 {
+		return fmt.Sprintf("category-%d.json", category)
+	}
+
+	return fmt.Sprintf("category-%d-offset-%d.json", category, offset)
+}
+
+func getCategoryTTL(cat int) time.Duration {
+	if ttl, ok := categoryTTLs[cat]; ok {
+		return ttl
+	}
+
+	return defaultCategoryTTL
+}
+
+func isAlwaysUsable(_ *entry) bool {
+	return true
+}
+
+func isNetworkError(err error) bool {
+	return !errors.Is(err, hn.ErrNotFound) && !errors.Is(err, context.Canceled)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
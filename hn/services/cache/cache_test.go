@@ -0,0 +1,115 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/hn/services/cache"
+	"clx/item"
+	"clx/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type service struct {
+	err   error
+	calls int
+}
+
+func (s *service) FetchItems(_ context.Context, _ int, _ int, _ int) ([]*item.Item, error) {
+	s.calls++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []*item.Item{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+}
+
+func (s *service) FetchItem(_ context.Context, id int) (*item.Item, error) {
+	s.calls++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &item.Item{ID: id}, nil
+}
+
+func (s *service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	return s.FetchItem(ctx, id)
+}
+
+func (s *service) FetchUser(_ context.Context, name string) (*user.User, error) {
+	return &user.User{Name: name}, nil
+}
+
+func TestFreshEntriesAreServedFromDisk(t *testing.T) {
+	t.Parallel()
+
+	backend := new(service)
+	c := &cache.Service{Service: backend, Directory: t.TempDir()}
+
+	first, err := c.FetchItems(context.Background(), 0, 3, category.FrontPage)
+	assert.NoError(t, err)
+
+	second, err := c.FetchItems(context.Background(), 0, 2, category.FrontPage)
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 3)
+	assert.Len(t, second, 2)
+	assert.Equal(t, 1, backend.calls)
+	assert.False(t, c.IsOffline())
+
+	_, _ = c.FetchItems(context.Background(), 0, 10, category.FrontPage)
+	assert.Equal(t, 2, backend.calls, "a larger request must not be served from a smaller list")
+}
+
+func TestFallsBackToExpiredEntriesWhenOffline(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	expired := `{"FetchedAt": 1, "Item": {"ID": 42, "Title": "Cached"}}`
+	_ = os.WriteFile(filepath.Join(dir, "comments-42.json"), []byte(expired), 0o600)
+
+	backend := &service{err: hn.ErrTimeout}
+	c := &cache.Service{Service: backend, Directory: dir}
+
+	story, err := c.FetchComments(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "Cached", story.Title)
+	assert.True(t, c.IsOffline())
+
+	_, err = c.FetchComments(context.Background(), 43)
+	assert.True(t, errors.Is(err, hn.ErrTimeout))
+
+	backend.err = nil
+
+	story, err = c.FetchComments(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "", story.Title)
+	assert.False(t, c.IsOffline())
+}
+
+func TestRefreshSkipsFreshEntries(t *testing.T) {
+	t.Parallel()
+
+	backend := new(service)
+	c := &cache.Service{Service: backend, Directory: t.TempDir()}
+
+	_, _ = c.FetchItems(context.Background(), 0, 3, category.FrontPage)
+	_, _ = c.FetchItems(hn.WithRefresh(context.Background()), 0, 3, category.FrontPage)
+	assert.Equal(t, 2, backend.calls)
+
+	backend.err = hn.ErrTimeout
+
+	items, err := c.FetchItems(hn.WithRefresh(context.Background()), 0, 3, category.FrontPage)
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.True(t, c.IsOffline())
+}
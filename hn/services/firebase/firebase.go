@@ -0,0 +1,398 @@
+package firebase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"clx/constants/category"
+	"clx/endpoints"
+	"clx/hn"
+	"clx/hn/services/internal/mapping"
+	"clx/item"
+	"clx/user"
+	"clx/utils/http"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+const (
+	// maxConcurrentRequests bounds the number of item requests that are in
+	// flight at the same time when walking the 'kids' graph
+	maxConcurrentRequests = 16
+)
+
+type Service struct {
+	// BaseURL defaults to endpoints.FirebaseURL if left empty
+	BaseURL string
+}
+
+func (s *Service) baseURL() string {
+	if s.BaseURL == "" {
+		return endpoints.FirebaseURL
+	}
+
+	return strings.TrimSuffix(s.BaseURL, "/")
+}
+
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	listOfIDs, err := s.FetchStoryIDs(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := Page(listOfIDs, offset, itemsToFetch)
+
+	stories, err := s.fetchAll(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch stories: %w", err)
+	}
+
+	now := time.Now()
+	items := make([]*item.Item, 0, len(ids))
+
+	for _, id := range ids {
+		story := stories[id]
+		if story == nil || story.Dead || story.Deleted {
+			continue
+		}
+
+		items = append(items, mapItem(story, 0, now))
+	}
+
+	return items, nil
+}
+
+// FetchStoryIDs returns the ranked list of IDs for the given category.
+func (s *Service) FetchStoryIDs(ctx context.Context, category int) ([]int, error) {
+	cat, err := getCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s.json", s.baseURL(), cat)
+
+	var stories []int
+
+	if err := http.Get(ctx, url, &stories); err != nil {
+		return nil, fmt.Errorf("could not fetch list of stories: %w", err)
+	}
+
+	return stories, nil
+}
+
+// Page returns at most limit IDs starting at offset. It returns an empty list
+// if offset is past the end of the list or limit is not positive.
+func Page(ids []int, offset int, limit int) []int {
+	start := min(offset, len(ids))
+	end := max(start, min(start+limit, len(ids)))
+
+	return ids[start:end]
+}
+
+func getCategory(cat int) (string, error) {
+	switch cat {
+	case category.FrontPage:
+		return "topstories", nil
+
+	case category.New:
+		return "newstories", nil
+
+	case category.Ask:
+		return "askstories", nil
+
+	case category.Show:
+		return "showstories", nil
+
+	case category.Best:
+		return "beststories", nil
+
+	case category.Jobs:
+		return "jobstories", nil
+
+	default:
+		// Launch HN submissions don't have a list of their own
+		return "", fmt.Errorf("%w: %d", hn.ErrUnsupportedCategory, cat)
+	}
+}
+
+// FetchUpdates returns the IDs of items that have changed recently.
+func (s *Service) FetchUpdates(ctx context.Context) ([]int, error) {
+	updates := new(endpoints.HNUpdates)
+	url := fmt.Sprintf("%s/updates.json", s.baseURL())
+
+	if err := http.Get(ctx, url, updates); err != nil {
+		return nil, fmt.Errorf("could not fetch updates: %w", err)
+	}
+
+	return updates.Items, nil
+}
+
+// FetchItemsByID fetches the given items without their comments. Items that
+// don't exist are left out.
+func (s *Service) FetchItemsByID(ctx context.Context, ids []int) ([]*item.Item, error) {
+	stories, err := s.fetchAll(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch items: %w", err)
+	}
+
+	now := time.Now()
+	items := make([]*item.Item, 0, len(stories))
+
+	for _, id := range ids {
+		if story := stories[id]; story != nil {
+			items = append(items, mapItem(story, 0, now))
+		}
+	}
+
+	return items, nil
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.fetch(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, err)
+	}
+
+	if story == nil {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, hn.ErrNotFound)
+	}
+
+	it := mapItem(story, 0, time.Now())
+
+	if it.PollOptions, err = s.fetchPollOptions(ctx, story.Parts); err != nil {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, err)
+	}
+
+	return it, nil
+}
+
+// fetchPollOptions fetches the 'parts' of a poll in the order in which they
+// were listed by the author.
+func (s *Service) fetchPollOptions(ctx context.Context, parts []int) ([]*item.PollOption, error) {
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	fetched, err := s.fetchAll(ctx, parts)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch poll options: %w", err)
+	}
+
+	options := make([]*item.PollOption, 0, len(parts))
+
+	for _, id := range parts {
+		if option := fetched[id]; option != nil {
+			options = append(options, &item.PollOption{ID: option.Id, Text: option.Text, Points: option.Score})
+		}
+	}
+
+	return options, nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*user.User, error) {
+	u := new(endpoints.HNUser)
+	url := fmt.Sprintf("%s/user/%s.json", s.baseURL(), name)
+
+	if err := http.Get(ctx, url, u); err != nil {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, err)
+	}
+
+	// Unknown users are answered with 'null'
+	if u.ID == "" {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, hn.ErrNotFound)
+	}
+
+	return &user.User{
+		Name:    u.ID,
+		Created: int64(u.Created),
+		Karma:   u.Karma,
+		About:   u.About,
+	}, nil
+}
+
+// FetchComments walks the 'kids' graph of the item one level at a time and
+// assembles the same tree that the hackerweb endpoint would have returned.
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	root, err := s.fetch(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, hn.ErrNotFound)
+	}
+
+	comments := make(map[int]*endpoints.HN)
+	nextLevel := root.Kids
+
+	for len(nextLevel) > 0 {
+		fetched, err := s.fetchAll(ctx, nextLevel)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+		}
+
+		var kids []int
+
+		for _, kidID := range nextLevel {
+			if c := fetched[kidID]; c != nil {
+				comments[kidID] = c
+				kids = append(kids, c.Kids...)
+			}
+		}
+
+		nextLevel = kids
+	}
+
+	now := time.Now()
+	story := mapItem(root, 0, now)
+	story.Comments = mapComments(root.Kids, comments, 0, now)
+
+	if story.PollOptions, err = s.fetchPollOptions(ctx, root.Parts); err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	return story, nil
+}
+
+func mapComments(ids []int, comments map[int]*endpoints.HN, level int, now time.Time) []*item.Item {
+	items := make([]*item.Item, 0, len(ids))
+
+	for _, id := range ids {
+		c := comments[id]
+		if c == nil {
+			continue
+		}
+
+		it := mapItem(c, level, now)
+		it.Comments = mapComments(c.Kids, comments, level+1, now)
+
+		items = append(items, it)
+	}
+
+	return items
+}
+
+func mapItem(story *endpoints.HN, level int, now time.Time) *item.Item {
+	content := story.Text
+	if content != "" && !strings.HasPrefix(content, "<p>") {
+		// The first paragraph from Firebase is not prefixed with <p> the way
+		// it is on hackerweb, which the comment parser expects
+		content = "<p>" + content
+	}
+
+	if story.Deleted || story.Dead {
+		content = "[deleted]"
+	}
+
+	return &item.Item{
+		ID:            story.Id,
+		Parent:        story.Parent,
+		Title:         story.Title,
+		Points:        story.Score,
+		User:          story.By,
+		Time:          int64(story.Time),
+		TimeAgo:       mapping.TimeAgo(int64(story.Time), now),
+		Type:          story.Type,
+		URL:           story.Url,
+		Level:         level,
+		Domain:        domainutil.Domain(story.Url),
+		Content:       content,
+		CommentsCount: story.Descendants,
+	}
+}
+
+// fetchAll fetches the given IDs with a bounded pool of workers. Items that
+// Firebase does not know about are left out of the returned map.
+func (s *Service) fetchAll(ctx context.Context, ids []int) (map[int]*endpoints.HN, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		jobs     = make(chan int)
+		results  = make(map[int]*endpoints.HN, len(ids))
+	)
+
+	for i := 0; i < min(maxConcurrentRequests, len(ids)); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for id := range jobs {
+				story, err := s.fetch(ctx, id)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+
+				if story != nil {
+					results[id] = story
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, id := range ids {
+		select {
+		case jobs <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, hn.ClassifyError(0, err)
+	}
+
+	return results, nil
+}
+
+// fetch returns nil without an error if the item does not exist, since
+// Firebase answers with 'null' rather than a 404 for unknown IDs.
+func (s *Service) fetch(ctx context.Context, id int) (*endpoints.HN, error) {
+	story := new(endpoints.HN)
+	url := fmt.Sprintf("%s/item/%d.json", s.baseURL(), id)
+
+	if err := http.Get(ctx, url, story); err != nil {
+		return nil, err
+	}
+
+	if story.Id == 0 {
+		return nil, nil
+	}
+
+	return story, nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
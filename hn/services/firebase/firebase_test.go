@@ -0,0 +1,166 @@
+package firebase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"clx/hn"
+	"clx/hn/services/firebase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var items = map[int]string{
+	1: `{"id":1,"type":"story","by":"alfa","title":"Title","url":"https://example.com/post","score":10,` +
+		`"time":1643215106,"descendants":4,"kids":[2,3]}`,
+	2: `{"id":2,"type":"comment","by":"beta","text":"First<p>Second","parent":1,"time":1643215106,"kids":[4]}`,
+	3: `{"id":3,"type":"comment","deleted":true,"parent":1,"time":1643215106}`,
+	4: `{"id":4,"type":"comment","by":"gamma","text":"Reply","parent":2,"time":1643215106,"kids":[5]}`,
+	5: `{"id":5,"type":"comment","by":"delta","text":"Nested reply","parent":4,"time":1643215106}`,
+	6: `{"id":6,"type":"poll","by":"alfa","title":"Poll","score":20,"time":1643215106,"parts":[7,8]}`,
+	7: `{"id":7,"type":"pollopt","by":"alfa","poll":6,"text":"Yes","score":3,"time":1643215106}`,
+	8: `{"id":8,"type":"pollopt","by":"alfa","poll":6,"text":"No","score":12,"time":1643215106}`,
+}
+
+func newServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/updates.json" {
+			_, _ = w.Write([]byte(`{"items":[1,42],"profiles":["alfa"]}`))
+
+			return
+		}
+
+		if r.URL.Path == "/user/alfa.json" {
+			_, _ = w.Write([]byte(`{"id":"alfa","created":1173923446,"karma":42,"about":"About me"}`))
+
+			return
+		}
+
+		for id, body := range items {
+			if r.URL.Path == fmt.Sprintf("/item/%d.json", id) {
+				_, _ = w.Write([]byte(body))
+
+				return
+			}
+		}
+
+		_, _ = w.Write([]byte("null"))
+	}))
+}
+
+func TestFetchComments(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	service := &firebase.Service{BaseURL: server.URL}
+
+	story, err := service.FetchComments(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Title", story.Title)
+	assert.Equal(t, "example.com", story.Domain)
+	assert.Equal(t, 4, story.CommentsCount)
+	assert.Len(t, story.Comments, 2)
+
+	first := story.Comments[0]
+	assert.Equal(t, "beta", first.User)
+	assert.Equal(t, 0, first.Level)
+	assert.Equal(t, "<p>First<p>Second", first.Content)
+	assert.NotEmpty(t, first.TimeAgo)
+	assert.Equal(t, "[deleted]", story.Comments[1].Content)
+
+	reply := first.Comments[0]
+	assert.Equal(t, 1, reply.Level)
+	assert.Equal(t, 2, reply.Comments[0].Level)
+	assert.Equal(t, "Nested reply", reply.Comments[0].Content[len("<p>"):])
+}
+
+func TestFetchItemNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	service := &firebase.Service{BaseURL: server.URL}
+
+	_, err := service.FetchItem(context.Background(), 42)
+
+	assert.True(t, errors.Is(err, hn.ErrNotFound))
+}
+
+func TestFetchPoll(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	service := &firebase.Service{BaseURL: server.URL}
+
+	poll, err := service.FetchComments(context.Background(), 6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "poll", poll.Type)
+	assert.Len(t, poll.PollOptions, 2)
+	assert.Equal(t, "Yes", poll.PollOptions[0].Text)
+	assert.Equal(t, 3, poll.PollOptions[0].Points)
+	assert.Equal(t, 12, poll.PollOptions[1].Points)
+}
+
+func TestFetchUser(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	service := &firebase.Service{BaseURL: server.URL}
+
+	u, err := service.FetchUser(context.Background(), "alfa")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alfa", u.Name)
+	assert.Equal(t, 42, u.Karma)
+	assert.Equal(t, int64(1173923446), u.Created)
+	assert.Equal(t, "About me", u.About)
+
+	_, err = service.FetchUser(context.Background(), "beta")
+
+	assert.True(t, errors.Is(err, hn.ErrNotFound))
+}
+
+func TestFetchUpdatedItems(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	service := &firebase.Service{BaseURL: server.URL}
+
+	updated, err := service.FetchUpdates(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 42}, updated)
+
+	items, err := service.FetchItemsByID(context.Background(), updated)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, 10, items[0].Points)
+	assert.Equal(t, 4, items[0].CommentsCount)
+}
+
+func TestPage(t *testing.T) {
+	t.Parallel()
+
+	ids := []int{1, 2, 3, 4, 5}
+
+	assert.Equal(t, []int{1, 2}, firebase.Page(ids, 0, 2))
+	assert.Equal(t, []int{4, 5}, firebase.Page(ids, 3, 10))
+	assert.Empty(t, firebase.Page(ids, 5, 2))
+	assert.Empty(t, firebase.Page(ids, 8, 2))
+	assert.Empty(t, firebase.Page(ids, 1, 0))
+	assert.Empty(t, firebase.Page(ids, 1, -1))
+}
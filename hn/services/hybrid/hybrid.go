@@ -1,74 +1,102 @@
 package hybrid
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
-	"clx/app"
-	"clx/constants/category"
+	cat "clx/constants/category"
 	"clx/endpoints"
+	"clx/hn"
+	"clx/hn/services/algolia"
+	"clx/hn/services/firebase"
+	"clx/hn/services/internal/mapping"
 	"clx/item"
+	"clx/user"
+	"clx/utils/http"
 
 	"github.com/bobesa/go-domain-util/domainutil"
-	"github.com/go-resty/resty/v2"
 )
 
 const (
-	uri = "https://hacker-news.firebaseio.com/v0"
+	CommentSourceHackerWeb = "hackerweb"
+	CommentSourceFirebase  = "firebase"
 )
 
-type Service struct{}
+type Service struct {
+	// CommentSource selects where comment trees are fetched from. It defaults
+	// to hackerweb if left empty.
+	CommentSource string
 
-func (s *Service) FetchItems(itemsToFetch int, category int) (items []*item.Item, errMsg string) {
-	// Posts of the type: 'Company (YC __) is hiring ...' is filtered out
-	// from Algolia. For this reason, we ask for one more item than we need.
-	itemsToFetchWithBuffer := itemsToFetch + 1
-	listOfIDs, errMsg := fetchStoriesList(category)
-	if errMsg != "" {
-		return nil, errMsg
+	// The base URLs default to the public endpoints if left empty
+	FirebaseURL  string
+	AlgoliaURL   string
+	HackerWebURL string
+}
+
+func (s *Service) firebase() *firebase.Service {
+	return &firebase.Service{BaseURL: s.FirebaseURL}
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*user.User, error) {
+	return s.firebase().FetchUser(ctx, name)
+}
+
+func (s *Service) algolia() *algolia.Service {
+	return &algolia.Service{BaseURL: s.AlgoliaURL}
+}
+
+func (s *Service) algoliaURL() string {
+	if s.AlgoliaURL == "" {
+		return endpoints.AlgoliaURL
 	}
 
-	ids := getStoryListURIParam(listOfIDs[0:itemsToFetchWithBuffer])
+	return strings.TrimSuffix(s.AlgoliaURL, "/")
+}
 
-	url := "https://hn.algolia.com/api/v1/search?tags=story," +
-		"(" + ids + ")&hitsPerPage=" + strconv.Itoa(itemsToFetchWithBuffer)
+func (s *Service) hackerWebURL() string {
+	if s.HackerWebURL == "" {
+		return endpoints.HackerWebURL
+	}
 
-	var a *endpoints.Algolia
+	return strings.TrimSuffix(s.HackerWebURL, "/")
+}
 
-	client := resty.New()
-	client.SetTimeout(10 * time.Second)
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	// Firebase has no list of Launch HN submissions, so they are searched
+	// for on Algolia instead
+	if category == cat.Launch {
+		return s.algolia().FetchItems(ctx, offset, itemsToFetch, category)
+	}
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(&a).
-		Get(url)
+	listOfIDs, err := s.firebase().FetchStoryIDs(ctx, category)
 	if err != nil {
-		return nil, err.Error()
+		return nil, err
 	}
 
-	mapOfItemsWithMetaData := mapStories(a)
-	orderedStories := joinStories(listOfIDs, mapOfItemsWithMetaData)
+	listOfIDs = firebase.Page(listOfIDs, offset, itemsToFetch)
+	if len(listOfIDs) == 0 {
+		return []*item.Item{}, nil
+	}
 
-	return orderedStories[0:min(itemsToFetch, len(orderedStories))], ""
-}
+	ids := getStoryListURIParam(listOfIDs)
 
-func fetchStoriesList(category int) (stories []int, errMsg string) {
-	url := fmt.Sprintf("%s/%s.json", uri, getCategory(category))
+	// Job postings and polls are tagged 'job' and 'poll' rather than 'story'
+	// on Algolia
+	url := s.algoliaURL() + "/search?tags=(story,job,poll)," +
+		"(" + ids + ")&hitsPerPage=" + strconv.Itoa(itemsToFetch)
 
-	client := resty.New()
-	client.SetTimeout(10 * time.Second)
+	a := new(endpoints.Algolia)
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(&stories).
-		Get(url)
-	if err != nil {
-		return nil, err.Error()
+	if err := http.Get(ctx, url, a); err != nil {
+		return nil, fmt.Errorf("could not fetch stories: %w", err)
 	}
 
-	return stories, ""
+	mapOfItemsWithMetaData := mapStories(a)
+	orderedStories := joinStories(listOfIDs, mapOfItemsWithMetaData)
+
+	return orderedStories[0:min(itemsToFetch, len(orderedStories))], nil
 }
 
 func getStoryListURIParam(ids []int) string {
@@ -81,25 +109,6 @@ func getStoryListURIParam(ids []int) string {
 	return sb.String()
 }
 
-func getCategory(cat int) string {
-	switch cat {
-	case category.FrontPage:
-		return "topstories"
-
-	case category.New:
-		return "newstories"
-
-	case category.Ask:
-		return "askstories"
-
-	case category.Show:
-		return "showstories"
-
-	default:
-		panic("Unsupported c: " + strconv.Itoa(cat))
-	}
-}
-
 func mapStories(stories *endpoints.Algolia) map[int]*item.Item {
 	m := make(map[int]*item.Item)
 
@@ -108,12 +117,12 @@ func mapStories(stories *endpoints.Algolia) map[int]*item.Item {
 
 		it := &item.Item{
 			ID:            id,
-			Title:         sanitize(story.Title),
+			Title:         mapping.Sanitize(story.Title),
 			Points:        story.Points,
 			User:          story.Author,
 			Time:          int64(story.CreatedAtI),
 			TimeAgo:       "",
-			Type:          "",
+			Type:          mapping.GetType(story.Tags),
 			URL:           story.URL,
 			Domain:        domainutil.Domain(story.URL),
 			Comments:      nil,
@@ -128,20 +137,6 @@ func mapStories(stories *endpoints.Algolia) map[int]*item.Item {
 	return m
 }
 
-func sanitize(s string) string {
-	var b strings.Builder
-
-	for _, c := range s {
-		if c == '­' {
-			continue
-		}
-
-		b.WriteRune(c)
-	}
-
-	return b.String()
-}
-
 func joinStories(orderedIds []int, stories map[int]*item.Item) []*item.Item {
 	var orderedStories []*item.Item
 
@@ -156,54 +151,43 @@ func joinStories(orderedIds []int, stories map[int]*item.Item) []*item.Item {
 	return orderedStories
 }
 
-func (s Service) FetchItem(id int) *item.Item {
-	hn := new(endpoints.HN)
-
-	client := resty.New()
-	client.SetTimeout(5 * time.Second)
-	client.SetBaseURL("https://hacker-news.firebaseio.com/v0/item/")
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	return s.firebase().FetchItem(ctx, id)
+}
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(hn).
-		Get(strconv.Itoa(id) + ".json")
-	if err != nil {
-		panic(err)
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	if s.CommentSource == CommentSourceFirebase {
+		return s.firebase().FetchComments(ctx, id)
 	}
 
-	return mapItem(hn)
-}
+	comments := new(endpoints.Comments)
+	url := s.hackerWebURL() + "/item/" + strconv.Itoa(id)
 
-func mapItem(hn *endpoints.HN) *item.Item {
-	return &item.Item{
-		ID:            hn.Id,
-		Title:         hn.Title,
-		Points:        hn.Score,
-		User:          hn.By,
-		Time:          int64(hn.Time),
-		TimeAgo:       "",
-		Type:          "",
-		URL:           hn.Url,
-		CommentsCount: hn.Descendants,
+	if err := http.Get(ctx, url, comments); err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
 	}
-}
 
-func (s Service) FetchComments(id int) *item.Item {
-	comments := new(endpoints.Comments)
+	if comments.ID == 0 {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, hn.ErrNotFound)
+	}
 
-	client := resty.New()
-	client.SetTimeout(5 * time.Second)
-	client.SetBaseURL("http://api.hackerwebapp.com/item/")
+	story := mapComments(comments)
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(comments).
-		Get(strconv.Itoa(id))
-	if err != nil {
-		panic(err)
+	for _, option := range comments.Poll {
+		story.PollOptions = append(story.PollOptions, &item.PollOption{Text: option.Item, Points: option.Points})
+	}
+
+	// Fall back to Firebase in case hackerweb leaves out the options
+	if story.Type == "poll" && len(story.PollOptions) == 0 {
+		poll, err := s.firebase().FetchItem(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+		}
+
+		story.PollOptions = poll.PollOptions
 	}
 
-	return mapComments(comments)
+	return story, nil
 }
 
 func mapComments(comments *endpoints.Comments) *item.Item {
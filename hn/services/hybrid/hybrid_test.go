@@ -0,0 +1,60 @@
+package hybrid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"clx/constants/category"
+	"clx/hn/services/hybrid"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var hits = map[string]string{
+	"story": `{"objectID":"1","title":"Story","author":"alfa","points":10,"_tags":["story","story_1"]}`,
+	"job":   `{"objectID":"2","title":"Job","author":"beta","_tags":["job","story_2"]}`,
+	"poll":  `{"objectID":"3","title":"Poll","author":"gamma","points":20,"_tags":["poll","story_3"]}`,
+}
+
+// newAlgoliaServer only returns the hits whose type is part of the first tag
+// group, like Algolia does
+func newAlgoliaServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		types, _, _ := strings.Cut(r.URL.Query().Get("tags"), ")")
+
+		var matching []string
+
+		for _, t := range strings.Split(strings.TrimPrefix(types, "("), ",") {
+			if hit, ok := hits[t]; ok {
+				matching = append(matching, hit)
+			}
+		}
+
+		_, _ = w.Write([]byte(`{"hits":[` + strings.Join(matching, ",") + `]}`))
+	}))
+}
+
+func TestFetchItemsIncludesPolls(t *testing.T) {
+	t.Parallel()
+
+	firebase := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[3,1,2]`))
+	}))
+	defer firebase.Close()
+
+	algolia := newAlgoliaServer()
+	defer algolia.Close()
+
+	service := &hybrid.Service{FirebaseURL: firebase.URL, AlgoliaURL: algolia.URL}
+
+	items, err := service.FetchItems(context.Background(), 0, 30, category.FrontPage)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, 3, items[0].ID)
+	assert.Equal(t, "poll", items[0].Type)
+	assert.Equal(t, "job", items[2].Type)
+}
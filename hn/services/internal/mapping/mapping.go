@@ -0,0 +1,51 @@
+// Package mapping holds the helpers that the services share for turning API
+// responses into items.
+package mapping
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nleeper/goment"
+)
+
+// TimeAgo returns how long before now the Unix time was, e.g. "2 hours ago".
+func TimeAgo(unixTime int64, now time.Time) string {
+	moment, err := goment.Unix(unixTime)
+	if err != nil {
+		return ""
+	}
+
+	reference, err := goment.New(now)
+	if err != nil {
+		return ""
+	}
+
+	return moment.From(reference)
+}
+
+// GetType returns the type of an item from its Algolia tags.
+func GetType(tags []string) string {
+	for _, tag := range tags {
+		if tag == "job" || tag == "poll" {
+			return tag
+		}
+	}
+
+	return "story"
+}
+
+// Sanitize removes soft hyphens from titles.
+func Sanitize(s string) string {
+	var b strings.Builder
+
+	for _, c := range s {
+		if c == '­' {
+			continue
+		}
+
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package mapping_test
+
+import (
+	"testing"
+	"time"
+
+	"clx/hn/services/internal/mapping"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeAgo(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1643215106, 0)
+
+	assert.Equal(t, "2 hours ago", mapping.TimeAgo(now.Add(-2*time.Hour).Unix(), now))
+}
+
+func TestGetType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "job", mapping.GetType([]string{"job", "story_1"}))
+	assert.Equal(t, "poll", mapping.GetType([]string{"poll", "author_alfa", "story_2"}))
+	assert.Equal(t, "story", mapping.GetType([]string{"story", "author_alfa"}))
+}
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Hyphenation", mapping.Sanitize("Hyphen­ation"))
+}
@@ -1,11 +1,15 @@
 package mock
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
 	"time"
 
 	"clx/constants/category"
+	"clx/hn"
 	"clx/item"
+	"clx/user"
 )
 
 type Service struct{}
@@ -13,13 +17,17 @@ type Service struct{}
 func (Service) Init(_ int) {
 }
 
-func (Service) FetchItems(_ int, cat int) (items []*item.Item, error string) {
+func (Service) FetchItems(ctx context.Context, offset int, _ int, cat int) ([]*item.Item, error) {
 	// Uncomment to test the spinner on startup
 	if cat != 0 {
-		time.Sleep(time.Second * 1)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second * 1):
+		}
 	}
 
-	items = []*item.Item{
+	items := []*item.Item{
 		{
 			Title:         "Lorem ipsum dolor sit amet et quasi architecto",
 			Points:        31,
@@ -288,10 +296,14 @@ func (Service) FetchItems(_ int, cat int) (items []*item.Item, error string) {
 		rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
 	}
 
-	return items, ""
+	if offset >= len(items) {
+		return []*item.Item{}, nil
+	}
+
+	return items[offset:], nil
 }
 
-func (Service) FetchComments(_ int) *item.Item {
+func (Service) FetchComments(_ context.Context, _ int) (*item.Item, error) {
 	return &item.Item{
 		ID:      32145667,
 		Title:   "Mauris commodo odio (YC W05) quis diam fermentum, et suscipit augue pharetra [video]",
@@ -416,9 +428,26 @@ func (Service) FetchComments(_ int) *item.Item {
 		Content: "<p>Lorem ipsum dolor sit amet, " +
 			"consectetur adipiscing elit. Integer a augue id elit efficitur tempor sit amet quis lectus.",
 		CommentsCount: 57,
-	}
+	}, nil
+}
+
+func (Service) FetchUser(_ context.Context, name string) (*user.User, error) {
+	return &user.User{
+		Name:    name,
+		Created: time.Now().AddDate(-3, 0, 0).Unix(),
+		Karma:   1337,
+		About:   "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.<p>Integer a augue id elit efficitur.",
+	}, nil
 }
 
-func (s Service) FetchItem(id int) *item.Item {
-	return nil
+func (s Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	items, _ := s.FetchItems(ctx, 0, 0, category.FrontPage)
+
+	for _, it := range items {
+		if it.ID == id {
+			return it, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not fetch item %d: %w", id, hn.ErrNotFound)
 }
@@ -0,0 +1,167 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"clx/file"
+	"clx/hn"
+	"clx/item"
+	"clx/user"
+)
+
+// Service plays back responses that were previously written to Directory by
+// a Recorder. It never touches the network, which makes it useful for demos,
+// bug reports and working on the UI without a connection.
+type Service struct {
+	Directory string
+}
+
+func (s *Service) FetchItems(_ context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	var items []*item.Item
+
+	if err := read(filepath.Join(s.Directory, categoryFileName(category)), &items); err != nil {
+		return nil, fmt.Errorf("could not replay stories: %w", err)
+	}
+
+	start := min(offset, len(items))
+
+	return items[start:min(start+itemsToFetch, len(items))], nil
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.FetchComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	story.Comments = nil
+
+	return story, nil
+}
+
+func (s *Service) FetchComments(_ context.Context, id int) (*item.Item, error) {
+	story := new(item.Item)
+
+	if err := read(filepath.Join(s.Directory, itemFileName(id)), story); err != nil {
+		return nil, fmt.Errorf("could not replay item %d: %w", id, err)
+	}
+
+	return story, nil
+}
+
+func (s *Service) FetchUser(_ context.Context, name string) (*user.User, error) {
+	u := new(user.User)
+
+	if err := read(filepath.Join(s.Directory, userFileName(name)), u); err != nil {
+		return nil, fmt.Errorf("could not replay user %s: %w", name, err)
+	}
+
+	return u, nil
+}
+
+// Recorder wraps a service and writes every successful response to Directory
+// so that it can be replayed later.
+type Recorder struct {
+	Service   hn.Service
+	Directory string
+}
+
+func (r *Recorder) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	items, err := r.Service.FetchItems(ctx, offset, itemsToFetch, category)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset == 0 {
+		return items, write(r.Directory, categoryFileName(category), items)
+	}
+
+	// Later pages are appended to the recording if it ends where they begin
+	var recorded []*item.Item
+
+	if err := read(filepath.Join(r.Directory, categoryFileName(category)), &recorded); err != nil ||
+		len(recorded) != offset {
+		return items, nil
+	}
+
+	return items, write(r.Directory, categoryFileName(category), append(recorded, items...))
+}
+
+func (r *Recorder) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story, err := r.Service.FetchItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't overwrite a recorded comment tree with the bare item
+	if file.Exists(filepath.Join(r.Directory, itemFileName(id))) {
+		return story, nil
+	}
+
+	return story, write(r.Directory, itemFileName(id), story)
+}
+
+func (r *Recorder) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	story, err := r.Service.FetchComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return story, write(r.Directory, itemFileName(id), story)
+}
+
+func (r *Recorder) FetchUser(ctx context.Context, name string) (*user.User, error) {
+	u, err := r.Service.FetchUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return u, write(r.Directory, userFileName(name), u)
+}
+
+func categoryFileName(category int) string {
+	return fmt.Sprintf("category-%d.json", category)
+}
+
+func itemFileName(id int) string {
+	return fmt.Sprintf("item-%d.json", id)
+}
+
+func userFileName(name string) string {
+	return fmt.Sprintf("user-%s.json", name)
+}
+
+func read(path string, v interface{}) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return hn.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, v)
+}
+
+func write(dirPath string, fileName string, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("could not serialize response: %w", err)
+	}
+
+	return file.WriteToFileNew(dirPath, fileName, string(content))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
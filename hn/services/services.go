@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"clx/hn"
+	"clx/hn/services/algolia"
+	"clx/hn/services/cache"
+	"clx/hn/services/firebase"
+	"clx/hn/services/hybrid"
+	"clx/hn/services/mock"
+	"clx/hn/services/replay"
+	"clx/offline"
+	"clx/settings"
+)
+
+const (
+	Hybrid   = "hybrid"
+	Firebase = "firebase"
+	Algolia  = "algolia"
+	Mock     = "mock"
+	Replay   = "replay"
+)
+
+var registry = map[string]func(config *settings.Config) hn.Service{
+	Hybrid: func(config *settings.Config) hn.Service {
+		return &hybrid.Service{
+			CommentSource: config.CommentSource,
+			FirebaseURL:   config.FirebaseURL,
+			AlgoliaURL:    config.AlgoliaURL,
+			HackerWebURL:  config.HackerWebURL,
+		}
+	},
+	Firebase: func(config *settings.Config) hn.Service {
+		return &firebase.Service{BaseURL: config.FirebaseURL}
+	},
+	Algolia: func(config *settings.Config) hn.Service {
+		return &algolia.Service{BaseURL: config.AlgoliaURL}
+	},
+	Mock: func(_ *settings.Config) hn.Service {
+		return mock.Service{}
+	},
+	Replay: func(config *settings.Config) hn.Service {
+		return &replay.Service{Directory: config.ReplayDirectory}
+	},
+}
+
+// New returns the backend selected in the config. Responses from the network
+// are cached on disk unless the cache is disabled. The hidden debug mode always
+// uses the mock backend and offline mode uses the snapshot from 'clx sync'.
+func New(config *settings.Config) (hn.Service, error) {
+	if config.Offline && !config.DebugMode {
+		return offline.New(config.OfflineDirectory), nil
+	}
+
+	name := config.Backend
+	if config.DebugMode {
+		name = Mock
+	}
+
+	newService, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend '%s', expected one of: %s", name, strings.Join(Names(), ", "))
+	}
+
+	service := newService(config)
+
+	if config.RecordResponses && name != Replay {
+		service = &replay.Recorder{Service: service, Directory: config.ReplayDirectory}
+	}
+
+	if !config.DisableCache && name != Mock && name != Replay {
+		service = &cache.Service{Service: service, Directory: config.CacheDirectory}
+	}
+
+	return service, nil
+}
+
+// UsesNetwork reports whether the configured backend fetches live data, as
+// opposed to mock data or recorded responses.
+func UsesNetwork(config *settings.Config) bool {
+	return !config.DebugMode && !config.Offline && config.Backend != Mock && config.Backend != Replay
+}
+
+// Names returns the names of all registered backends in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,69 @@
+package hn
+
+import (
+	"context"
+	"fmt"
+
+	"clx/item"
+)
+
+// maxThreadDepth guards against following parents forever in case a backend
+// returns a cycle
+const maxThreadDepth = 1000
+
+// FindStory follows the parents of an item up to the story it was posted to.
+// It returns the story along with the IDs of the comments leading from the
+// story down to the item, which are empty if the item is a story itself.
+func FindStory(ctx context.Context, service Service, id int) (*item.Item, []int, error) {
+	var path []int
+
+	for depth := 0; depth < maxThreadDepth; depth++ {
+		it, err := service.FetchItem(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if it.Type != "comment" {
+			return it, path, nil
+		}
+
+		if it.Parent == 0 {
+			return nil, nil, fmt.Errorf("could not find the story of comment %d", it.ID)
+		}
+
+		path = append([]int{it.ID}, path...)
+		id = it.Parent
+	}
+
+	return nil, nil, fmt.Errorf("could not find the story of item %d", id)
+}
+
+// Focus returns a copy of the story that only contains the comments on the
+// given path. The last comment on the path keeps all of its replies.
+func Focus(story *item.Item, path []int) (*item.Item, error) {
+	focused := *story
+	parent := &focused
+
+	for _, id := range path {
+		c := findComment(parent.Comments, id)
+		if c == nil {
+			return nil, fmt.Errorf("could not find comment %d in item %d: %w", id, story.ID, ErrNotFound)
+		}
+
+		copied := *c
+		parent.Comments = []*item.Item{&copied}
+		parent = &copied
+	}
+
+	return &focused, nil
+}
+
+func findComment(comments []*item.Item, id int) *item.Item {
+	for _, c := range comments {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package hn_test
+
+import (
+	"context"
+	"testing"
+
+	"clx/hn"
+	"clx/item"
+	"clx/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type service map[int]*item.Item
+
+func (s service) FetchItems(context.Context, int, int, int) ([]*item.Item, error) {
+	return nil, nil
+}
+
+func (s service) FetchItem(_ context.Context, id int) (*item.Item, error) {
+	if it, ok := s[id]; ok {
+		return it, nil
+	}
+
+	return nil, hn.ErrNotFound
+}
+
+func (s service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	return s.FetchItem(ctx, id)
+}
+
+func (s service) FetchUser(context.Context, string) (*user.User, error) {
+	return nil, hn.ErrNotFound
+}
+
+func TestFindStory(t *testing.T) {
+	t.Parallel()
+
+	s := service{
+		1: {ID: 1, Type: "story"},
+		2: {ID: 2, Parent: 1, Type: "comment"},
+		3: {ID: 3, Parent: 2, Type: "comment"},
+	}
+
+	story, path, err := hn.FindStory(context.Background(), s, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, story.ID)
+	assert.Equal(t, []int{2, 3}, path)
+
+	story, path, err = hn.FindStory(context.Background(), s, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, story.ID)
+	assert.Empty(t, path)
+
+	_, _, err = hn.FindStory(context.Background(), s, 4)
+
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+}
+
+func TestFocus(t *testing.T) {
+	t.Parallel()
+
+	reply := &item.Item{ID: 4}
+	focused := &item.Item{ID: 3, Comments: []*item.Item{reply}}
+	story := &item.Item{ID: 1, Comments: []*item.Item{
+		{ID: 2, Comments: []*item.Item{{ID: 5}, focused}},
+		{ID: 6},
+	}}
+
+	thread, err := hn.Focus(story, []int{2, 3})
+
+	assert.NoError(t, err)
+	assert.Len(t, thread.Comments, 1)
+	assert.Equal(t, 2, thread.Comments[0].ID)
+	assert.Len(t, thread.Comments[0].Comments, 1)
+	assert.Equal(t, 3, thread.Comments[0].Comments[0].ID)
+	assert.Equal(t, []*item.Item{reply}, thread.Comments[0].Comments[0].Comments)
+
+	// The story itself is left untouched
+	assert.Len(t, story.Comments, 2)
+	assert.Len(t, story.Comments[0].Comments, 2)
+
+	_, err = hn.Focus(story, []int{6, 3})
+
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+}
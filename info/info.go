@@ -22,6 +22,8 @@ func GetText(screenWidth int, enableNerdFonts bool) string {
 	keys.AddSeparator()
 	keys.AddKeymap("Refresh", "r")
 	keys.AddKeymap("Change category", "Tab")
+	keys.AddKeymap("Search", "/")
+	keys.AddKeymap("View profile of author", "u")
 	keys.AddSeparator()
 	keys.AddKeymap("Open story link in browser", "o")
 	keys.AddKeymap("Open comments in browser", "c")
@@ -29,6 +31,11 @@ func GetText(screenWidth int, enableNerdFonts bool) string {
 	keys.AddKeymap("Add to favorites", "f")
 	keys.AddKeymap("Remove from favorites", "x")
 	keys.AddSeparator()
+	keys.AddKeymap("Upvote / favorite / flag on HN", "U, F, X")
+	keys.AddKeymap("Reply on HN", "R")
+	keys.AddKeymap("Export thread to Markdown", "E")
+	keys.AddSeparator()
+	keys.AddKeymap("Change settings", "S")
 	keys.AddKeymap("Bring up this screen", "i, ?")
 	keys.AddKeymap("Quit to prompt", "q")
 	keys.AddSeparator()
@@ -41,6 +48,10 @@ func GetText(screenWidth int, enableNerdFonts bool) string {
 	keys.AddKeymap("Hide / show all replies", "h, l")
 	keys.AddKeymap("Next / prev top-level comment", "n, N")
 	keys.AddSeparator()
+	keys.AddKeymap("Upvote / favorite / flag story on HN", "U, F, X")
+	keys.AddKeymap("Reply to story on HN", "R")
+	keys.AddKeymap("Export thread to Markdown", "E")
+	keys.AddSeparator()
 	keys.AddKeymap("Return to circumflex", "q")
 	keys.AddSeparator()
 
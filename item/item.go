@@ -2,6 +2,7 @@ package item
 
 type Item struct {
 	ID            int
+	Parent        int
 	Title         string
 	Points        int
 	User          string
@@ -14,4 +15,11 @@ type Item struct {
 	Comments      []*Item
 	Content       string
 	CommentsCount int
+	PollOptions   []*PollOption
+}
+
+type PollOption struct {
+	ID     int
+	Text   string
+	Points int
 }
@@ -8,10 +8,15 @@ import (
 //go:embed lesskey
 var lesskey string
 
+//go:embed comment-section
+var commentSectionKeys string
+
 type Lesskey struct {
-	tempLesskeyFile *os.File
+	tempLesskeyFile        *os.File
+	tempCommentSectionFile *os.File
 }
 
+// NewLesskey writes the lesskey file that every view uses.
 func NewLesskey() *Lesskey {
 	tempLesskeyFile, _ := os.CreateTemp("", "lesskey*")
 	_, _ = tempLesskeyFile.WriteString(lesskey)
@@ -22,10 +27,31 @@ func NewLesskey() *Lesskey {
 	return key
 }
 
+// NewLesskeyWithCommentSection also writes the lesskey file that binds the
+// actions of the comment section in the main view.
+func NewLesskeyWithCommentSection() *Lesskey {
+	key := NewLesskey()
+
+	tempCommentSectionFile, _ := os.CreateTemp("", "lesskey*")
+	_, _ = tempCommentSectionFile.WriteString(lesskey + "\n" + commentSectionKeys)
+
+	key.tempCommentSectionFile = tempCommentSectionFile
+
+	return key
+}
+
 func (key *Lesskey) GetPath() string {
 	return key.tempLesskeyFile.Name()
 }
 
+func (key *Lesskey) GetCommentSectionPath() string {
+	return key.tempCommentSectionFile.Name()
+}
+
 func (key *Lesskey) Remove() {
 	_ = os.Remove(key.tempLesskeyFile.Name())
+
+	if key.tempCommentSectionFile != nil {
+		_ = os.Remove(key.tempCommentSectionFile.Name())
+	}
 }
@@ -2,7 +2,9 @@ package meta
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"clx/constants/nerdfonts"
 
@@ -11,6 +13,7 @@ import (
 	"clx/item"
 	"clx/settings"
 	"clx/syntax"
+	"clx/user"
 
 	text "github.com/MichaelMure/go-term-text"
 
@@ -65,7 +68,83 @@ func GetCommentSectionMetaBlock(c *item.Item, config *settings.Config, newCommen
 	joined := lipgloss.JoinHorizontal(lipgloss.Left, leftColumn.Render(leftColumnText),
 		rightColumn.Render(rightColumnText))
 
-	return getHeadline(c.Title, config) + newParagraph + style.Render(url+joined+rootComment)
+	return getHeadline(c.Title, config) + newParagraph +
+		style.Render(url+joined+rootComment+getPollOptions(c.PollOptions, config.CommentWidth-2))
+}
+
+// getPollOptions ranks the options of a poll by votes and draws a bar for each
+// of them that is scaled to the option with the most votes.
+func getPollOptions(options []*item.PollOption, lineWidth int) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	ranked := make([]*item.PollOption, len(options))
+	copy(ranked, options)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Points > ranked[j].Points
+	})
+
+	totalVotes := 0
+	for _, option := range ranked {
+		totalVotes += option.Points
+	}
+
+	maxVotes := ranked[0].Points
+	labelWidth := len(fmt.Sprintf(" %d votes (100%%)", maxVotes))
+	barWidth := lineWidth - labelWidth
+
+	var sb strings.Builder
+
+	for i, option := range ranked {
+		optionText := syntax.ReplaceCharacters(strings.ReplaceAll(option.Text, "<p>", " "))
+		rank := Faint(fmt.Sprintf("%d.", i+1)).String()
+		wrappedText, _ := text.Wrap(rank+" "+optionText, lineWidth)
+
+		percentage := 0
+		bar := 0
+
+		if totalVotes > 0 {
+			percentage = option.Points * 100 / totalVotes
+		}
+
+		if maxVotes > 0 && barWidth > 0 {
+			bar = option.Points * barWidth / maxVotes
+		}
+
+		sb.WriteString(newParagraph + wrappedText + newLine)
+		sb.WriteString(Yellow(strings.Repeat("█", bar)).String())
+		sb.WriteString(Faint(fmt.Sprintf(" %d votes (%d%%)", option.Points, percentage)).String())
+	}
+
+	return sb.String()
+}
+
+func GetUserProfileMetaBlock(u *user.User, config *settings.Config) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		PaddingLeft(1).
+		PaddingRight(1).
+		Width(config.CommentWidth)
+
+	// The first paragraph of the 'about' field is not prefixed with <p>, which
+	// the comment parser expects
+	about := u.About
+	if about != "" && !strings.HasPrefix(about, "<p>") {
+		about = "<p>" + about
+	}
+
+	info := getAuthor(u.Name, config.EnableNerdFonts) + " " + Faint("joined "+u.JoinedAgo()).String() + newLine +
+		getKarma(u.Karma)
+
+	headline := unicode.ZeroWidthSpace + " " + newLine + Bold(u.Name).String()
+
+	return headline + newParagraph + style.Render(info+parseRootComment(about, config)) + newParagraph
+}
+
+func getKarma(karma int) string {
+	return fmt.Sprintf("%s karma", Yellow(strconv.Itoa(karma)).String())
 }
 
 func getAuthor(author string, enableNerdFonts bool) string {
@@ -0,0 +1,77 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"clx/file"
+	"clx/hn"
+	"clx/hn/services/replay"
+	"clx/item"
+	"clx/user"
+)
+
+const manifestFileName = "snapshot.json"
+
+// manifest marks a directory as a snapshot and records how it was synced
+type manifest struct {
+	SyncedAt     int64
+	Categories   []int
+	Limit        int
+	WithArticles bool
+}
+
+// Exists reports whether a snapshot has been synced to directory.
+func Exists(directory string) bool {
+	return file.Exists(filepath.Join(directory, manifestFileName))
+}
+
+// Service browses a snapshot that has been downloaded with Sync. Stories and
+// comments are stored in the format of the replay backend.
+type Service struct {
+	replay replay.Service
+}
+
+func New(directory string) *Service {
+	return &Service{replay: replay.Service{Directory: directory}}
+}
+
+// IsOffline always reports true so that the main view marks the stories as
+// coming from the snapshot.
+func (s *Service) IsOffline() bool {
+	return true
+}
+
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	items, err := s.replay.FetchItems(ctx, offset, itemsToFetch, category)
+
+	return items, notSynced(err)
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.replay.FetchItem(ctx, id)
+
+	return story, notSynced(err)
+}
+
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.replay.FetchComments(ctx, id)
+
+	return story, notSynced(err)
+}
+
+func (s *Service) FetchUser(_ context.Context, name string) (*user.User, error) {
+	return nil, fmt.Errorf("could not fetch user %s: %w", name, hn.ErrNotSynced)
+}
+
+// notSynced tells apart data that is missing from the snapshot from items
+// that do not exist on Hacker News.
+func notSynced(err error) error {
+	if errors.Is(err, hn.ErrNotFound) {
+		return fmt.Errorf("%w: %v", hn.ErrNotSynced, err)
+	}
+
+	return err
+}
@@ -0,0 +1,223 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"clx/file"
+	"clx/hn"
+	"clx/hn/services/replay"
+	"clx/item"
+	"clx/reader"
+)
+
+// maxConcurrentDownloads bounds the number of threads and articles that are
+// downloaded at the same time
+const maxConcurrentDownloads = 8
+
+type Options struct {
+	Categories []int
+	Limit      int
+
+	// Favorites are downloaded in addition to the stories of the categories
+	Favorites []*item.Item
+
+	WithArticles bool
+
+	// Report is called for every thread or article that could not be
+	// downloaded. Those are left out of the snapshot.
+	Report func(err error)
+}
+
+type Summary struct {
+	Stories  int
+	Threads  int
+	Articles int
+	Failed   int
+}
+
+// Sync downloads the stories of the categories along with their comments and,
+// optionally, their articles to directory. The snapshot is written next to
+// directory first and only replaces the previous one once it is complete.
+func Sync(ctx context.Context, service hn.Service, directory string, options Options) (Summary, error) {
+	var summary Summary
+
+	staging := directory + ".sync"
+
+	if err := os.RemoveAll(staging); err != nil {
+		return summary, fmt.Errorf("could not remove incomplete snapshot: %w", err)
+	}
+
+	defer os.RemoveAll(staging)
+
+	recorder := &replay.Recorder{Service: service, Directory: staging}
+	stories := append([]*item.Item{}, options.Favorites...)
+
+	for _, category := range options.Categories {
+		items, err := recorder.FetchItems(ctx, 0, options.Limit, category)
+		if err != nil {
+			return summary, fmt.Errorf("could not download stories: %w", err)
+		}
+
+		stories = append(stories, items...)
+	}
+
+	stories = unique(stories)
+	summary.Stories = len(stories)
+
+	var mu sync.Mutex
+
+	download(ctx, stories, func(story *item.Item) {
+		_, threadErr := recorder.FetchComments(ctx, story.ID)
+
+		var articleErr error
+
+		hasArticle := options.WithArticles && story.URL != ""
+		if hasArticle {
+			articleErr = reader.SaveArticle(ctx, staging, story.URL)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if threadErr != nil {
+			summary.fail(options.Report, fmt.Errorf("could not download comments for item %d: %w", story.ID,
+				threadErr))
+		} else {
+			summary.Threads++
+		}
+
+		if articleErr != nil {
+			summary.fail(options.Report, fmt.Errorf("could not download article of item %d: %w", story.ID,
+				articleErr))
+		} else if hasArticle {
+			summary.Articles++
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return summary, err
+	}
+
+	if err := writeManifest(staging, options); err != nil {
+		return summary, err
+	}
+
+	if err := removeSnapshot(directory); err != nil {
+		return summary, err
+	}
+
+	if err := os.Rename(staging, directory); err != nil {
+		return summary, fmt.Errorf("could not replace previous snapshot: %w", err)
+	}
+
+	return summary, nil
+}
+
+func writeManifest(directory string, options Options) error {
+	content, err := json.Marshal(manifest{SyncedAt: time.Now().Unix(), Categories: options.Categories,
+		Limit: options.Limit, WithArticles: options.WithArticles})
+	if err != nil {
+		return fmt.Errorf("could not serialize manifest: %w", err)
+	}
+
+	if err := file.WriteToFileNew(directory, manifestFileName, string(content)); err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+
+	return nil
+}
+
+// removeSnapshot refuses to remove a directory that has not been created by
+// Sync, in case the snapshot has been pointed at a directory with other files.
+func removeSnapshot(directory string) error {
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not read previous snapshot: %w", err)
+	}
+
+	if len(entries) > 0 && !Exists(directory) {
+		return fmt.Errorf("%s is not empty and does not contain a snapshot", directory)
+	}
+
+	if err := os.RemoveAll(directory); err != nil {
+		return fmt.Errorf("could not remove previous snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Summary) fail(report func(err error), err error) {
+	s.Failed++
+
+	if report != nil {
+		report(err)
+	}
+}
+
+// download calls fetch for every story, with a bounded number of calls in
+// flight. It stops handing out stories once ctx is done.
+func download(ctx context.Context, stories []*item.Item, fetch func(story *item.Item)) {
+	var (
+		wg   sync.WaitGroup
+		jobs = make(chan *item.Item)
+	)
+
+	for i := 0; i < min(maxConcurrentDownloads, len(stories)); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for story := range jobs {
+				fetch(story)
+			}
+		}()
+	}
+
+feed:
+	for _, story := range stories {
+		select {
+		case jobs <- story:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+func unique(stories []*item.Item) []*item.Item {
+	seen := make(map[int]bool)
+	filtered := make([]*item.Item, 0, len(stories))
+
+	for _, story := range stories {
+		if seen[story.ID] {
+			continue
+		}
+
+		seen[story.ID] = true
+
+		filtered = append(filtered, story)
+	}
+
+	return filtered
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,125 @@
+package offline_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/item"
+	"clx/offline"
+	"clx/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type service struct {
+	articleURL string
+}
+
+func (s service) FetchItems(_ context.Context, _ int, _ int, cat int) ([]*item.Item, error) {
+	if cat != category.FrontPage {
+		return nil, hn.ErrUnsupportedCategory
+	}
+
+	return []*item.Item{
+		{ID: 1, Title: "Story", URL: s.articleURL},
+		{ID: 2, Title: "Ask HN: Question"},
+		{ID: 3, Title: "Deleted"},
+	}, nil
+}
+
+func (s service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	return s.FetchComments(ctx, id)
+}
+
+func (s service) FetchComments(_ context.Context, id int) (*item.Item, error) {
+	if id == 3 {
+		return nil, hn.ErrNotFound
+	}
+
+	return &item.Item{ID: id, Comments: []*item.Item{{ID: id * 10, Content: "Comment"}}}, nil
+}
+
+func (s service) FetchUser(context.Context, string) (*user.User, error) {
+	return nil, hn.ErrNotFound
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body><p>Article</p></body></html>"))
+	}))
+	defer server.Close()
+
+	directory := filepath.Join(t.TempDir(), "offline")
+
+	var reported []error
+
+	options := offline.Options{
+		Categories:   []int{category.FrontPage},
+		Limit:        30,
+		Favorites:    []*item.Item{{ID: 2}, {ID: 4}},
+		WithArticles: true,
+		Report: func(err error) {
+			reported = append(reported, err)
+		},
+	}
+
+	summary, err := offline.Sync(context.Background(), service{articleURL: server.URL}, directory, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, offline.Summary{Stories: 4, Threads: 3, Articles: 1, Failed: 1}, summary)
+	assert.Len(t, reported, 1)
+	assert.True(t, offline.Exists(directory))
+
+	snapshot := offline.New(directory)
+
+	items, err := snapshot.FetchItems(context.Background(), 0, 2, category.FrontPage)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.True(t, snapshot.IsOffline())
+
+	comments, err := snapshot.FetchComments(context.Background(), 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 40, comments.Comments[0].ID)
+
+	_, err = snapshot.FetchComments(context.Background(), 3)
+
+	assert.ErrorIs(t, err, hn.ErrNotSynced)
+
+	_, err = snapshot.FetchItems(context.Background(), 0, 30, category.New)
+
+	assert.ErrorIs(t, err, hn.ErrNotSynced)
+}
+
+func TestSyncKeepsOtherFiles(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	notes := filepath.Join(directory, "notes.txt")
+
+	assert.NoError(t, os.WriteFile(notes, []byte("Not a snapshot"), 0o600))
+
+	options := offline.Options{Categories: []int{category.FrontPage}, Limit: 30}
+
+	_, err := offline.Sync(context.Background(), service{}, directory, options)
+
+	assert.Error(t, err)
+	assert.FileExists(t, notes)
+
+	options.Categories = []int{category.New}
+
+	_, err = offline.Sync(context.Background(), service{}, directory, options)
+
+	assert.ErrorIs(t, err, hn.ErrUnsupportedCategory)
+	assert.FileExists(t, notes)
+}
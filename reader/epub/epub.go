@@ -0,0 +1,136 @@
+// Package epub writes a single article as an EPUB 3 book.
+package epub
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"text/template"
+	"time"
+)
+
+const mimetype = "application/epub+zip"
+
+type Book struct {
+	Title  string
+	Source string
+	// Body is the article as XHTML. Images must refer to the names of Images.
+	Body   string
+	Images []Image
+}
+
+type Image struct {
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+var files = template.Must(template.New("container").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+{{define "content"}}<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="id">{{.Source}}</dc:identifier>
+    <dc:title>{{.Title}}</dc:title>
+    <dc:language>en</dc:language>
+    <dc:source>{{.Source}}</dc:source>
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="article" href="article.xhtml" media-type="application/xhtml+xml"/>
+{{- range $i, $image := .Images}}
+    <item id="image{{$i}}" href="{{$image.Name}}" media-type="{{$image.MediaType}}"/>
+{{- end}}
+  </manifest>
+  <spine>
+    <itemref idref="article"/>
+  </spine>
+</package>
+{{end}}{{define "nav"}}<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>{{.Title}}</title></head>
+<body>
+  <nav epub:type="toc">
+    <ol><li><a href="article.xhtml">{{.Title}}</a></li></ol>
+  </nav>
+</body>
+</html>
+{{end}}{{define "article"}}<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title>{{.Title}}</title></head>
+<body>
+{{.Body}}
+</body>
+</html>
+{{end}}`))
+
+// Write writes the book to w. The templates are executed with text/template,
+// so Title and Source are escaped here and Body is written as is.
+func Write(w io.Writer, book Book) error {
+	archive := zip.NewWriter(w)
+
+	// The mimetype must come first and must not be compressed
+	mime, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("could not create EPUB: %w", err)
+	}
+
+	if _, err := io.WriteString(mime, mimetype); err != nil {
+		return fmt.Errorf("could not create EPUB: %w", err)
+	}
+
+	data := struct {
+		Title    string
+		Source   string
+		Body     string
+		Modified string
+		Images   []Image
+	}{
+		Title:    html.EscapeString(book.Title),
+		Source:   html.EscapeString(book.Source),
+		Body:     book.Body,
+		Modified: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		Images:   book.Images,
+	}
+
+	for _, file := range []struct{ name, template string }{
+		{"META-INF/container.xml", "container"},
+		{"OEBPS/content.opf", "content"},
+		{"OEBPS/nav.xhtml", "nav"},
+		{"OEBPS/article.xhtml", "article"},
+	} {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", file.name, err)
+		}
+
+		if err := files.ExecuteTemplate(f, file.template, data); err != nil {
+			return fmt.Errorf("could not write %s: %w", file.name, err)
+		}
+	}
+
+	for _, image := range book.Images {
+		f, err := archive.Create("OEBPS/" + image.Name)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", image.Name, err)
+		}
+
+		if _, err := f.Write(image.Data); err != nil {
+			return fmt.Errorf("could not write %s: %w", image.Name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("could not create EPUB: %w", err)
+	}
+
+	return nil
+}
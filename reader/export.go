@@ -0,0 +1,162 @@
+package reader
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	nethttp "net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"clx/reader/epub"
+	"clx/reader/markdown/document"
+	"clx/utils/http"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatEPUB     = "epub"
+
+	imageTimeout = 15 * time.Second
+)
+
+var (
+	imageSource = regexp.MustCompile(`<img src="([^"]+)"`)
+
+	imageExtensions = map[string]string{
+		"image/jpeg":    "jpg",
+		"image/png":     "png",
+		"image/gif":     "gif",
+		"image/webp":    "webp",
+		"image/svg+xml": "svg",
+	}
+)
+
+func Formats() []string {
+	return []string{FormatMarkdown, FormatHTML, FormatEPUB}
+}
+
+// Export writes the article as Markdown, HTML or EPUB. Images are downloaded
+// and embedded in HTML and EPUB so that the article can be read offline.
+func Export(w io.Writer, url string, title string, format string) error {
+	if format != FormatMarkdown && format != FormatHTML && format != FormatEPUB {
+		return fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats(), ", "))
+	}
+
+	markdownBlocks, err := getMarkdownBlocks(url)
+	if err != nil {
+		return err
+	}
+
+	articleInMarkdown := document.CreateHeader(title, url) + document.ConvertToMarkdown(markdownBlocks)
+
+	if format == FormatMarkdown {
+		return write(w, articleInMarkdown)
+	}
+
+	body, err := document.ConvertToHTML(articleInMarkdown)
+	if err != nil {
+		return err
+	}
+
+	images := fetchImages(body)
+
+	if format == FormatHTML {
+		page, err := document.CreatePage(title, replaceImageSources(body, images, dataURI))
+		if err != nil {
+			return err
+		}
+
+		return write(w, page)
+	}
+
+	book := epub.Book{
+		Title:  title,
+		Source: url,
+		Body:   replaceImageSources(body, images, func(image epub.Image) string { return image.Name }),
+	}
+
+	for _, image := range images {
+		book.Images = append(book.Images, image)
+	}
+
+	sort.Slice(book.Images, func(i, j int) bool {
+		return book.Images[i].Name < book.Images[j].Name
+	})
+
+	return epub.Write(w, book)
+}
+
+// fetchImages downloads the images of the article. Images that cannot be
+// fetched keep pointing to their original location.
+func fetchImages(body string) map[string]epub.Image {
+	images := make(map[string]epub.Image)
+
+	for _, match := range imageSource.FindAllStringSubmatch(body, -1) {
+		src := match[1]
+		if _, ok := images[src]; ok {
+			continue
+		}
+
+		image, err := fetchImage(html.UnescapeString(src))
+		if err != nil {
+			continue
+		}
+
+		image.Name = fmt.Sprintf("images/%d.%s", len(images), imageExtensions[image.MediaType])
+		images[src] = image
+	}
+
+	return images
+}
+
+func fetchImage(url string) (epub.Image, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), imageTimeout)
+	defer cancel()
+
+	data, contentType, err := http.GetPage(ctx, url)
+	if err != nil {
+		return epub.Image{}, err
+	}
+
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	if _, ok := imageExtensions[mediaType]; !ok {
+		mediaType = nethttp.DetectContentType(data)
+	}
+
+	if _, ok := imageExtensions[mediaType]; !ok {
+		return epub.Image{}, fmt.Errorf("unsupported image type '%s'", mediaType)
+	}
+
+	return epub.Image{MediaType: mediaType, Data: data}, nil
+}
+
+func replaceImageSources(body string, images map[string]epub.Image, source func(image epub.Image) string) string {
+	return imageSource.ReplaceAllStringFunc(body, func(tag string) string {
+		src := imageSource.FindStringSubmatch(tag)[1]
+
+		image, ok := images[src]
+		if !ok {
+			return tag
+		}
+
+		return `<img src="` + source(image) + `"`
+	})
+}
+
+func dataURI(image epub.Image) string {
+	return "data:" + image.MediaType + ";base64," + base64.StdEncoding.EncodeToString(image.Data)
+}
+
+func write(w io.Writer, text string) error {
+	if _, err := io.WriteString(w, text); err != nil {
+		return fmt.Errorf("could not write article: %w", err)
+	}
+
+	return nil
+}
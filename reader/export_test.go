@@ -0,0 +1,96 @@
+package reader_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"clx/reader"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A 1x1 transparent GIF
+var pixel = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00!\xf9\x04\x01\x00\x00\x00\x00," +
+	"\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+const article = `<html><head><title>Example</title></head><body><article>
+<h1>Example</h1>
+<p>The first paragraph of the article is long enough to be kept by readability. It talks about <em>things</em>
+and goes on for a while so that the content is not discarded as boilerplate by the parser.</p>
+<p><img src="/pixel.gif" alt="A pixel"></p>
+<pre><code>x := 1</code></pre>
+<p>The second paragraph is also quite long and continues to discuss things in more detail, which should be plenty
+of text for the readability algorithm to consider this the main content of the page.</p>
+</article></body></html>`
+
+func newServer() *httptest.Server {
+	return httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.URL.Path == "/pixel.gif" {
+			w.Header().Set("Content-Type", "image/gif")
+			_, _ = w.Write(pixel)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(article))
+	}))
+}
+
+func TestExportMarkdown(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+
+	err := reader.Export(&buf, server.URL+"/article", "Example", reader.FormatMarkdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "# Example\n\nSource: <"+server.URL+"/article>")
+	assert.Contains(t, buf.String(), "*things*")
+	assert.Contains(t, buf.String(), "![A pixel]("+server.URL+"/pixel.gif)")
+	assert.Contains(t, buf.String(), "```\nx := 1\n```")
+	assert.NotContains(t, buf.String(), "CLX")
+}
+
+func TestExportEPUB(t *testing.T) {
+	t.Parallel()
+
+	server := newServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+
+	err := reader.Export(&buf, server.URL+"/article", "Example", reader.FormatEPUB)
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	files := make(map[string]string)
+
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+
+		files[f.Name] = string(content)
+	}
+
+	assert.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+	assert.Equal(t, "application/epub+zip", files["mimetype"])
+	assert.Equal(t, string(pixel), files["OEBPS/images/0.gif"])
+	assert.Contains(t, files["OEBPS/content.opf"], `href="images/0.gif" media-type="image/gif"`)
+	assert.Contains(t, files["OEBPS/content.opf"], "<dc:source>"+server.URL+"/article</dc:source>")
+	assert.Contains(t, files["OEBPS/article.xhtml"], `<img src="images/0.gif" alt="A pixel" />`)
+	assert.Contains(t, files["OEBPS/article.xhtml"], "<h1>Example</h1>")
+}
@@ -0,0 +1,118 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"clx/reader/markdown"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var escaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`)
+
+var page = template.Must(template.New("article").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8" />
+<title>{{.Title}}</title>
+<style>
+body { font-family: serif; max-width: 40em; margin: 2em auto; padding: 0 1em; line-height: 1.6; }
+img { max-width: 100%; }
+pre { overflow-x: auto; background: #f6f6f6; padding: 0.5em; }
+blockquote { color: #555; border-left: 2px solid #ddd; margin-left: 0; padding-left: 1em; }
+</style>
+</head>
+<body>
+<article>
+{{.Body}}
+</article>
+</body>
+</html>
+`))
+
+// CreateHeader returns the title and the link to the source of the article
+// in Markdown.
+func CreateHeader(title string, url string) string {
+	return fmt.Sprintf("# %s\n\nSource: <%s>\n\n", Escape(title), url)
+}
+
+// Escape keeps text such as titles and usernames from being read as Markdown
+// formatting.
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// ConvertToMarkdown turns the blocks back into plain Markdown without the
+// internal formatting tags.
+func ConvertToMarkdown(blocks []*markdown.Block) string {
+	output := make([]string, 0, len(blocks))
+
+	for _, block := range blocks {
+		switch block.Kind {
+		case markdown.Code:
+			output = append(output, "```\n"+strings.Trim(block.Text, "\n")+"\n```")
+
+		case markdown.Quote:
+			output = append(output, "> "+strings.ReplaceAll(block.Text, "\n", "\n> "))
+
+		case markdown.Divider:
+			output = append(output, "---")
+
+		default:
+			output = append(output, block.Text)
+		}
+	}
+
+	return replaceFormattingTags(strings.Join(output, "\n\n")) + "\n"
+}
+
+// ConvertToHTML renders Markdown as XHTML so that the output can be used both
+// on its own and inside an EPUB.
+func ConvertToHTML(text string) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.Table),
+		goldmark.WithRendererOptions(html.WithXHTML()))
+
+	var buf bytes.Buffer
+
+	if err := md.Convert([]byte(text), &buf); err != nil {
+		return "", fmt.Errorf("could not convert Markdown to HTML: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// CreatePage wraps the HTML body of an article in a standalone page.
+func CreatePage(title string, body string) (string, error) {
+	var buf bytes.Buffer
+
+	data := struct {
+		Title string
+		Body  template.HTML
+	}{
+		Title: title,
+		Body:  template.HTML(body),
+	}
+
+	if err := page.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not create page: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func replaceFormattingTags(text string) string {
+	text = strings.ReplaceAll(text, markdown.BoldStart, "")
+	text = strings.ReplaceAll(text, markdown.BoldStop, "")
+
+	text = strings.ReplaceAll(text, markdown.ItalicStart, "*")
+	text = strings.ReplaceAll(text, markdown.ItalicStop, "*")
+
+	return text
+}
@@ -0,0 +1,68 @@
+package reader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"clx/file"
+	"clx/hn"
+	"clx/utils/http"
+)
+
+// savedArticles is the directory that articles are read from instead of the
+// network. It is empty unless UseSavedArticles has been called.
+var savedArticles string
+
+// UseSavedArticles makes reader mode read the articles that have been saved
+// to directory with SaveArticle instead of fetching them.
+func UseSavedArticles(directory string) {
+	savedArticles = directory
+}
+
+// SaveArticle downloads the page at url to directory so that it can be read
+// without a connection.
+func SaveArticle(ctx context.Context, directory string, url string) error {
+	page, contentType, err := http.GetPage(ctx, url)
+	if err != nil {
+		return fmt.Errorf("could not fetch url: %w", err)
+	}
+
+	if !strings.Contains(contentType, "text/html") {
+		return errors.New("url is not an HTML document")
+	}
+
+	if err := file.WriteToFileNew(directory, articleFileName(url), string(page)); err != nil {
+		return fmt.Errorf("could not save article: %w", err)
+	}
+
+	return nil
+}
+
+func getPage(ctx context.Context, url string) ([]byte, string, error) {
+	if savedArticles == "" {
+		return http.GetPage(ctx, url)
+	}
+
+	page, err := os.ReadFile(filepath.Join(savedArticles, articleFileName(url)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", hn.ErrNotSynced
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read saved article: %w", err)
+	}
+
+	return page, "text/html", nil
+}
+
+func articleFileName(url string) string {
+	sum := sha256.Sum256([]byte(url))
+
+	return "article-" + hex.EncodeToString(sum[:16]) + ".html"
+}
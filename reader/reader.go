@@ -1,9 +1,14 @@
 package reader
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"time"
+	nurl "net/url"
+	"strings"
 
+	"clx/reader/markdown"
 	"clx/reader/markdown/postprocessor"
 	"clx/reader/markdown/terminal"
 
@@ -14,23 +19,48 @@ import (
 )
 
 func GetArticle(url string, title string, width int, indentationSymbol string) (string, error) {
-	articleInRawHTML, httpErr := readability.FromURL(url, 5*time.Second)
+	markdownBlocks, err := getMarkdownBlocks(url)
+	if err != nil {
+		return "", err
+	}
+
+	articleInTerminalFormal := terminal.ConvertToTerminalFormat(markdownBlocks, width, indentationSymbol)
+
+	header := terminal.CreateHeader(title, url, width)
+
+	articleInTerminalFormal = postprocessor.Process(header+articleInTerminalFormal, url)
+
+	return articleInTerminalFormal, nil
+}
+
+func getMarkdownBlocks(url string) ([]*markdown.Block, error) {
+	articleInRawHTML, httpErr := fetchArticle(url)
 	if httpErr != nil {
-		return "", fmt.Errorf("could not fetch url: %w", httpErr)
+		return nil, fmt.Errorf("could not fetch url: %w", httpErr)
 	}
 
 	articleInMarkdown, mdErr := html.ConvertToMarkdown(articleInRawHTML.Content)
 	if mdErr != nil {
-		return "", fmt.Errorf("could not fetch url: %w", httpErr)
+		return nil, fmt.Errorf("could not convert article to Markdown: %w", mdErr)
 	}
 
-	markdownBlocks := parser.ConvertToMarkdownBlocks(articleInMarkdown)
+	return parser.ConvertToMarkdownBlocks(articleInMarkdown), nil
+}
 
-	articleInTerminalFormal := terminal.ConvertToTerminalFormat(markdownBlocks, width, indentationSymbol)
+func fetchArticle(url string) (readability.Article, error) {
+	pageURL, err := nurl.ParseRequestURI(url)
+	if err != nil {
+		return readability.Article{}, fmt.Errorf("could not parse url: %w", err)
+	}
 
-	header := terminal.CreateHeader(title, url, width)
+	page, contentType, err := getPage(context.Background(), url)
+	if err != nil {
+		return readability.Article{}, err
+	}
 
-	articleInTerminalFormal = postprocessor.Process(header+articleInTerminalFormal, url)
+	if !strings.Contains(contentType, "text/html") {
+		return readability.Article{}, errors.New("url is not an HTML document")
+	}
 
-	return articleInTerminalFormal, nil
+	return readability.FromReader(bytes.NewReader(page), pageURL)
 }
@@ -0,0 +1,376 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"clx/endpoints"
+	"clx/file"
+	clxhttp "clx/utils/http"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const cookieName = "user"
+
+var (
+	ErrNotLoggedIn = errors.New("not logged in")
+	ErrBadLogin    = errors.New("bad login")
+
+	// ErrUnavailable is returned if the item page has no link for the action,
+	// e.g. when upvoting an item twice or flagging without enough karma.
+	ErrUnavailable = errors.New("action not available")
+
+	// ErrRejected is returned if HN answers a post with a page instead of a
+	// redirect, e.g. when posting too fast.
+	ErrRejected = errors.New("rejected by Hacker News")
+)
+
+type Action int
+
+const (
+	Upvote Action = iota
+	Favorite
+	Flag
+)
+
+func (a Action) String() string {
+	switch a {
+	case Upvote:
+		return "upvote"
+
+	case Favorite:
+		return "favorite"
+
+	default:
+		return "flag"
+	}
+}
+
+// Session performs actions on Hacker News on behalf of a logged-in user. The
+// login cookie is the only state and is kept on disk between runs.
+type Session struct {
+	// BaseURL defaults to news.ycombinator.com if left empty
+	BaseURL string
+
+	// CookiePath defaults to the session file in the config directory if
+	// left empty
+	CookiePath string
+}
+
+func (s *Session) baseURL() string {
+	if s.BaseURL == "" {
+		return endpoints.HackerNewsURL
+	}
+
+	return strings.TrimSuffix(s.BaseURL, "/")
+}
+
+func (s *Session) cookiePath() string {
+	if s.CookiePath == "" {
+		return file.PathToSessionFile()
+	}
+
+	return s.CookiePath
+}
+
+// Login submits the login form and stores the cookie that is returned on
+// success.
+func (s *Session) Login(ctx context.Context, username string, password string) error {
+	form := map[string]string{
+		"acct": username,
+		"pw":   password,
+		"goto": "news",
+	}
+
+	resp, err := clxhttp.PostForm(ctx, s.baseURL()+"/login", form, nil)
+	if err != nil {
+		return fmt.Errorf("could not log in: %w", err)
+	}
+
+	for _, cookie := range resp.Cookies {
+		if cookie.Name == cookieName && cookie.Value != "" {
+			return s.writeCookie(cookie.Value)
+		}
+	}
+
+	return fmt.Errorf("could not log in: %w", ErrBadLogin)
+}
+
+func (s *Session) Logout() error {
+	if err := os.Remove(s.cookiePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove session: %w", err)
+	}
+
+	return nil
+}
+
+// Username returns the name of the logged-in user, which is the first part
+// of the cookie.
+func (s *Session) Username() (string, error) {
+	value, err := s.readCookie()
+	if err != nil {
+		return "", err
+	}
+
+	name, _, _ := strings.Cut(value, "&")
+
+	return name, nil
+}
+
+// Do performs action on the item with the given ID. The auth token that HN
+// requires is scraped from the item page first.
+func (s *Session) Do(ctx context.Context, id int, action Action) error {
+	value, err := s.readCookie()
+	if err != nil {
+		return fmt.Errorf("could not %s item %d: %w", action, id, err)
+	}
+
+	cookies := []*http.Cookie{{Name: cookieName, Value: value}}
+
+	page, err := clxhttp.GetWithCookies(ctx, s.baseURL()+"/item?id="+strconv.Itoa(id), cookies)
+	if err != nil {
+		return fmt.Errorf("could not %s item %d: %w", action, id, err)
+	}
+
+	auth, err := scrapeAuthToken(page.Body, id, action)
+	if err != nil {
+		return fmt.Errorf("could not %s item %d: %w", action, id, err)
+	}
+
+	resp, err := clxhttp.GetWithCookies(ctx, s.actionURL(id, action, auth), cookies)
+	if err != nil {
+		return fmt.Errorf("could not %s item %d: %w", action, id, err)
+	}
+
+	// An expired cookie sends us back to the login form
+	if strings.HasPrefix(resp.Location, "login") || bytes.Contains(resp.Body, []byte("Bad login")) {
+		return fmt.Errorf("could not %s item %d: %w", action, id, ErrNotLoggedIn)
+	}
+
+	return nil
+}
+
+// Reply posts text as a reply to the item with the given ID. The form and its
+// CSRF token are scraped from the reply page.
+func (s *Session) Reply(ctx context.Context, id int, text string) error {
+	err := s.submitForm(ctx, "/reply?id="+strconv.Itoa(id), "comment", map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("could not reply to item %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Submit posts a new story. Either storyURL or text may be empty.
+func (s *Session) Submit(ctx context.Context, title string, storyURL string, text string) error {
+	fields := map[string]string{
+		"title": title,
+		"url":   storyURL,
+		"text":  text,
+	}
+
+	if err := s.submitForm(ctx, "/submit", "/r", fields); err != nil {
+		return fmt.Errorf("could not submit story: %w", err)
+	}
+
+	return nil
+}
+
+// submitForm fetches the page at pagePath, fills in the form that posts to
+// action and submits it together with its hidden fields.
+func (s *Session) submitForm(ctx context.Context, pagePath string, action string, fields map[string]string) error {
+	value, err := s.readCookie()
+	if err != nil {
+		return err
+	}
+
+	cookies := []*http.Cookie{{Name: cookieName, Value: value}}
+
+	page, err := clxhttp.GetWithCookies(ctx, s.baseURL()+pagePath, cookies)
+	if err != nil {
+		return err
+	}
+
+	form, err := scrapeForm(page.Body, action)
+	if err != nil {
+		return err
+	}
+
+	for name, field := range fields {
+		form[name] = field
+	}
+
+	resp, err := clxhttp.PostForm(ctx, s.baseURL()+"/"+strings.TrimPrefix(action, "/"), form, cookies)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasPrefix(resp.Location, "login"):
+		return ErrNotLoggedIn
+
+	case resp.Location == "":
+		return fmt.Errorf("%w: %s", ErrRejected, getPageText(resp.Body))
+
+	default:
+		return nil
+	}
+}
+
+// scrapeForm returns the hidden fields of the form that posts to action,
+// which include the CSRF token.
+func scrapeForm(page []byte, action string) (map[string]string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse page: %w", err)
+	}
+
+	if doc.Find("a#me").Length() == 0 {
+		return nil, ErrNotLoggedIn
+	}
+
+	form := doc.Find("form[action='" + action + "']")
+	if form.Length() == 0 {
+		return nil, ErrUnavailable
+	}
+
+	fields := make(map[string]string)
+
+	form.Find("input[type='hidden']").Each(func(_ int, input *goquery.Selection) {
+		name, _ := input.Attr("name")
+		value, _ := input.Attr("value")
+
+		fields[name] = value
+	})
+
+	return fields, nil
+}
+
+// getPageText returns the first line of text of a page, which is where HN
+// puts its error messages.
+func getPageText(page []byte) string {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func (s *Session) actionURL(id int, action Action, auth string) string {
+	params := url.Values{}
+	params.Set("id", strconv.Itoa(id))
+	params.Set("auth", auth)
+	params.Set("goto", "item?id="+strconv.Itoa(id))
+
+	switch action {
+	case Upvote:
+		params.Set("how", "up")
+
+		return s.baseURL() + "/vote?" + params.Encode()
+
+	case Favorite:
+		return s.baseURL() + "/fave?" + params.Encode()
+
+	default:
+		return s.baseURL() + "/flag?" + params.Encode()
+	}
+}
+
+// scrapeAuthToken finds the link for action on the item page and returns its
+// auth parameter. Links that undo an action (unvote, un-favorite, unflag) are
+// not matched.
+func scrapeAuthToken(page []byte, id int, action Action) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return "", fmt.Errorf("could not parse item page: %w", err)
+	}
+
+	if doc.Find("a#me").Length() == 0 {
+		return "", ErrNotLoggedIn
+	}
+
+	prefix := map[Action]string{
+		Upvote:   "vote?",
+		Favorite: "fave?",
+		Flag:     "flag?",
+	}[action]
+
+	auth := ""
+
+	doc.Find("a[href^='" + prefix + "']").EachWithBreak(func(_ int, link *goquery.Selection) bool {
+		href, _ := link.Attr("href")
+
+		params, err := url.ParseQuery(strings.TrimPrefix(href, prefix))
+		if err != nil || params.Get("id") != strconv.Itoa(id) || params.Get("un") != "" {
+			return true
+		}
+
+		if action == Upvote && (params.Get("how") != "up" || link.HasClass("nosee")) {
+			return true
+		}
+
+		auth = params.Get("auth")
+
+		return auth == ""
+	})
+
+	if auth == "" {
+		return "", ErrUnavailable
+	}
+
+	return auth, nil
+}
+
+func (s *Session) readCookie() (string, error) {
+	value, err := os.ReadFile(s.cookiePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotLoggedIn
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("could not read session: %w", err)
+	}
+
+	if trimmed := strings.TrimSpace(string(value)); trimmed != "" {
+		return trimmed, nil
+	}
+
+	return "", ErrNotLoggedIn
+}
+
+// writeCookie stores the cookie so that only the current user can read it.
+// The permissions are set explicitly because WriteFile keeps the mode of an
+// existing file.
+func (s *Session) writeCookie(value string) error {
+	path := s.cookiePath()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create path to config dir: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+		return fmt.Errorf("could not write session: %w", err)
+	}
+
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("could not write session: %w", err)
+	}
+
+	return nil
+}
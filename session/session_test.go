@@ -0,0 +1,175 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"clx/session"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const itemPage = `<html><body>
+<a id="me" href="user?id=alfa">alfa</a>
+<a id="up_1" href="vote?id=1&amp;how=up&amp;auth=token1&amp;goto=item%3Fid%3D1"></a>
+<a href="flag?id=1&amp;auth=token1&amp;goto=item%3Fid%3D1">flag</a>
+<a href="fave?id=1&amp;auth=token1&amp;un=t">un-favorite</a>
+</body></html>`
+
+const replyPage = `<html><body>
+<a id="me" href="user?id=alfa">alfa</a>
+<form action="comment" method="post"><input type="hidden" name="parent" value="1">
+<input type="hidden" name="hmac" value="csrf1"><textarea name="text"></textarea></form>
+</body></html>`
+
+// fakeHN accepts the password 'secret' for the user 'alfa' and records the
+// actions and replies that are made with a valid cookie.
+type fakeHN struct {
+	mu      sync.Mutex
+	actions []string
+	replies []string
+}
+
+func (f *fakeHN) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/login" {
+		if r.FormValue("acct") == "alfa" && r.FormValue("pw") == "secret" {
+			http.SetCookie(w, &http.Cookie{Name: "user", Value: "alfa&cookie"})
+			http.Redirect(w, r, "news", http.StatusFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("Bad login."))
+
+		return
+	}
+
+	cookie, err := r.Cookie("user")
+	if err != nil || cookie.Value != "alfa&cookie" {
+		http.Redirect(w, r, "login?goto=news", http.StatusFound)
+
+		return
+	}
+
+	switch r.URL.Path {
+	case "/item":
+		_, _ = w.Write([]byte(itemPage))
+
+	case "/reply":
+		_, _ = w.Write([]byte(replyPage))
+
+	case "/comment":
+		if r.FormValue("hmac") != "csrf1" || r.FormValue("parent") != "1" {
+			_, _ = w.Write([]byte("Please try again."))
+
+			return
+		}
+
+		if r.FormValue("text") == "too fast" {
+			_, _ = w.Write([]byte("<html><body>\n\nYou're posting too fast.</body></html>"))
+
+			return
+		}
+
+		f.mu.Lock()
+		f.replies = append(f.replies, r.FormValue("text"))
+		f.mu.Unlock()
+
+		http.Redirect(w, r, "item?id=1", http.StatusFound)
+
+	case "/vote", "/flag":
+		if r.FormValue("auth") != "token1" {
+			_, _ = w.Write([]byte("Bad login."))
+
+			return
+		}
+
+		f.mu.Lock()
+		f.actions = append(f.actions, r.URL.Path+"?id="+r.FormValue("id"))
+		f.mu.Unlock()
+
+		http.Redirect(w, r, r.FormValue("goto"), http.StatusFound)
+	}
+}
+
+func TestLoginAndUpvote(t *testing.T) {
+	t.Parallel()
+
+	hn := new(fakeHN)
+	server := httptest.NewServer(hn)
+	defer server.Close()
+
+	cookiePath := filepath.Join(t.TempDir(), "session")
+	s := &session.Session{BaseURL: server.URL, CookiePath: cookiePath}
+
+	err := s.Do(context.Background(), 1, session.Upvote)
+	assert.True(t, errors.Is(err, session.ErrNotLoggedIn))
+
+	err = s.Login(context.Background(), "alfa", "wrong")
+	assert.True(t, errors.Is(err, session.ErrBadLogin))
+
+	assert.NoError(t, s.Login(context.Background(), "alfa", "secret"))
+
+	info, err := os.Stat(cookiePath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	name, err := s.Username()
+	assert.NoError(t, err)
+	assert.Equal(t, "alfa", name)
+
+	assert.NoError(t, s.Do(context.Background(), 1, session.Upvote))
+	assert.NoError(t, s.Do(context.Background(), 1, session.Flag))
+	assert.Equal(t, []string{"/vote?id=1", "/flag?id=1"}, hn.actions)
+
+	// The item is already a favorite, so the page only has an un-favorite link
+	err = s.Do(context.Background(), 1, session.Favorite)
+	assert.True(t, errors.Is(err, session.ErrUnavailable))
+
+	assert.NoError(t, s.Logout())
+
+	_, err = s.Username()
+	assert.True(t, errors.Is(err, session.ErrNotLoggedIn))
+}
+
+func TestExpiredCookie(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(new(fakeHN))
+	defer server.Close()
+
+	cookiePath := filepath.Join(t.TempDir(), "session")
+	assert.NoError(t, os.WriteFile(cookiePath, []byte("alfa&expired"), 0o600))
+
+	s := &session.Session{BaseURL: server.URL, CookiePath: cookiePath}
+
+	err := s.Do(context.Background(), 1, session.Upvote)
+
+	assert.True(t, errors.Is(err, session.ErrNotLoggedIn))
+}
+
+func TestReply(t *testing.T) {
+	t.Parallel()
+
+	hn := new(fakeHN)
+	server := httptest.NewServer(hn)
+	defer server.Close()
+
+	cookiePath := filepath.Join(t.TempDir(), "session")
+	assert.NoError(t, os.WriteFile(cookiePath, []byte("alfa&cookie"), 0o600))
+
+	s := &session.Session{BaseURL: server.URL, CookiePath: cookiePath}
+
+	assert.NoError(t, s.Reply(context.Background(), 1, "Great *post*"))
+	assert.Equal(t, []string{"Great *post*"}, hn.replies)
+
+	err := s.Reply(context.Background(), 1, "too fast")
+	assert.True(t, errors.Is(err, session.ErrRejected))
+	assert.Contains(t, err.Error(), "You're posting too fast.")
+}
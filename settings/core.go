@@ -1,5 +1,15 @@
 package settings
 
+import (
+	"path"
+	"time"
+
+	"clx/app"
+	"clx/endpoints"
+	"clx/file"
+	"clx/indent"
+)
+
 type Config struct {
 	CommentWidth                int
 	DisableHeadlineHighlighting bool
@@ -11,13 +21,55 @@ type Config struct {
 	DebugMode                   bool
 	EnableNerdFonts             bool
 	LesskeyPath                 string
+	CommentSectionLesskeyPath   string
 	AutoExpandComments          bool
 	NoLessVerify                bool
+	CommentSource               string
+	Backend                     string
+	FirebaseURL                 string
+	AlgoliaURL                  string
+	HackerWebURL                string
+	HackerNewsURL               string
+	ReplayDirectory             string
+	RecordResponses             bool
+	DisableCache                bool
+	CacheDirectory              string
+	Timeout                     time.Duration
+	UserAgent                   string
+	Proxy                       string
+	MaxConcurrentRequests       int
+	RequestsPerSecond           int
+	AutoRefresh                 time.Duration
+	Offline                     bool
+	OfflineDirectory            string
 }
 
 func Default() *Config {
 	return &Config{
-		CommentWidth:      70,
-		IndentationSymbol: " ▎",
+		CommentWidth:          70,
+		IndentationSymbol:     " ▎",
+		CommentSource:         "hackerweb",
+		Backend:               "hybrid",
+		FirebaseURL:           endpoints.FirebaseURL,
+		AlgoliaURL:            endpoints.AlgoliaURL,
+		HackerWebURL:          endpoints.HackerWebURL,
+		HackerNewsURL:         endpoints.HackerNewsURL,
+		ReplayDirectory:       path.Join(file.PathToCacheDirectory(), "replay"),
+		CacheDirectory:        path.Join(file.PathToCacheDirectory(), "responses"),
+		OfflineDirectory:      path.Join(file.PathToCacheDirectory(), "offline"),
+		Timeout:               10 * time.Second,
+		UserAgent:             app.Name + "/" + app.Version,
+		MaxConcurrentRequests: 16,
+	}
+}
+
+// IndentationSymbol returns the symbol to indent replies with. The configured
+// symbol is kept unless indentation is hidden or it is the default, which is
+// replaced with the symbol that works in the current terminal.
+func IndentationSymbol(configured string, hide bool) string {
+	if hide || configured == Default().IndentationSymbol {
+		return indent.GetIndentSymbol(hide)
 	}
+
+	return configured
 }
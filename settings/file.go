@@ -0,0 +1,330 @@
+package settings
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvironmentPrefix is prepended to the keys of the config file to form the
+// names of environment variables, e.g. CLX_COMMENT_WIDTH
+const EnvironmentPrefix = "CLX_"
+
+// Option maps a key in the config file onto a field of Config and the flag
+// that overrides it. Description is only set for options without a flag,
+// otherwise the usage of the flag describes the option.
+type Option struct {
+	Key         string
+	Flag        string
+	Field       string
+	Description string
+}
+
+// The lesskey paths are not options because they are set when less is started
+var options = []Option{
+	{Key: "COMMENT_WIDTH", Flag: "comment-width", Field: "CommentWidth"},
+	{Key: "PLAIN_HEADLINES", Flag: "plain-headlines", Field: "DisableHeadlineHighlighting"},
+	{Key: "PLAIN_COMMENTS", Flag: "plain-comments", Field: "DisableCommentHighlighting"},
+	{Key: "DISABLE_EMOJIS", Flag: "disable-emojis", Field: "DisableEmojis"},
+	{Key: "DISABLE_HISTORY", Flag: "disable-history", Field: "DoNotMarkSubmissionsAsRead"},
+	{Key: "HIDE_INDENT", Flag: "hide-indent", Field: "HideIndentSymbol"},
+	{Key: "INDENTATION_SYMBOL", Field: "IndentationSymbol",
+		Description: "set the symbol to the left of replies in the comment section"},
+	{Key: "NERDFONTS", Flag: "nerdfonts", Field: "EnableNerdFonts"},
+	{Key: "AUTO_EXPAND", Flag: "auto-expand", Field: "AutoExpandComments"},
+	{Key: "NO_LESS_VERIFY", Flag: "no-less-verify", Field: "NoLessVerify"},
+	{Key: "COMMENT_SOURCE", Flag: "comment-source", Field: "CommentSource"},
+	{Key: "BACKEND", Flag: "backend", Field: "Backend"},
+	{Key: "FIREBASE_URL", Flag: "firebase-url", Field: "FirebaseURL"},
+	{Key: "ALGOLIA_URL", Flag: "algolia-url", Field: "AlgoliaURL"},
+	{Key: "HACKERWEB_URL", Flag: "hackerweb-url", Field: "HackerWebURL"},
+	{Key: "HN_URL", Flag: "hn-url", Field: "HackerNewsURL"},
+	{Key: "REPLAY_DIR", Flag: "replay-dir", Field: "ReplayDirectory"},
+	{Key: "RECORD", Flag: "record", Field: "RecordResponses"},
+	{Key: "DISABLE_CACHE", Flag: "disable-cache", Field: "DisableCache"},
+	{Key: "CACHE_DIR", Field: "CacheDirectory", Description: "set the directory that responses are cached in"},
+	{Key: "TIMEOUT", Flag: "timeout", Field: "Timeout"},
+	{Key: "USER_AGENT", Flag: "user-agent", Field: "UserAgent"},
+	{Key: "PROXY", Flag: "proxy", Field: "Proxy"},
+	{Key: "MAX_CONCURRENT_REQUESTS", Flag: "max-concurrent-requests", Field: "MaxConcurrentRequests"},
+	{Key: "REQUESTS_PER_SECOND", Flag: "requests-per-second", Field: "RequestsPerSecond"},
+	{Key: "AUTO_REFRESH", Flag: "auto-refresh", Field: "AutoRefresh"},
+	{Key: "OFFLINE", Flag: "offline", Field: "Offline"},
+	{Key: "OFFLINE_DIR", Flag: "offline-dir", Field: "OfflineDirectory"},
+	{Key: "DEBUG_MODE", Flag: "debug-mode", Field: "DebugMode"},
+}
+
+// LoadError lists every problem found in the config file and the environment
+// so that they can be fixed in one go.
+type LoadError struct {
+	Problems []string
+}
+
+func (e *LoadError) Error() string {
+	return strings.Join(e.Problems, "\n")
+}
+
+func Options() []Option {
+	return options
+}
+
+// Load returns the defaults overridden by the config file at path, if it
+// exists, and then by the environment. The config is complete even if an
+// error is returned, with invalid values left at the previous level. Unknown
+// keys in the file are errors, while unknown environment variables are
+// skipped since they may be meant for another version of clx. Those are
+// listed by UnknownVariables.
+func Load(path string, environ []string) (*Config, error) {
+	config := Default()
+	loadErr := new(LoadError)
+
+	entries, err := ReadFile(path)
+	if errors.As(err, &loadErr) {
+		err = nil
+	}
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return config, err
+	}
+
+	for _, e := range entries {
+		if err := config.Set(e.Key, e.Value); err != nil {
+			loadErr.Problems = append(loadErr.Problems, fmt.Sprintf("%s:%d: %s", path, e.Line, err))
+		}
+	}
+
+	for _, variable := range environ {
+		name, value, _ := strings.Cut(variable, "=")
+		key := strings.TrimPrefix(name, EnvironmentPrefix)
+
+		if !strings.HasPrefix(name, EnvironmentPrefix) || !isOption(key) {
+			continue
+		}
+
+		if err := config.Set(key, value); err != nil {
+			loadErr.Problems = append(loadErr.Problems, fmt.Sprintf("environment variable %s: %s", name, err))
+		}
+	}
+
+	if len(loadErr.Problems) > 0 {
+		return config, loadErr
+	}
+
+	return config, nil
+}
+
+// UnknownVariables returns the names of the environment variables with the
+// prefix that do not match an option.
+func UnknownVariables(environ []string) []string {
+	var unknown []string
+
+	for _, variable := range environ {
+		name, _, _ := strings.Cut(variable, "=")
+
+		if strings.HasPrefix(name, EnvironmentPrefix) && !isOption(strings.TrimPrefix(name, EnvironmentPrefix)) {
+			unknown = append(unknown, name)
+		}
+	}
+
+	return unknown
+}
+
+type Entry struct {
+	Line  int
+	Key   string
+	Value string
+}
+
+// ReadFile reads KEY=VALUE lines. Blank lines and lines starting with # are
+// skipped, values may be quoted and lines may start with 'export'.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var (
+		entries []Entry
+		lineErr = new(LoadError)
+	)
+
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok {
+			lineErr.Problems = append(lineErr.Problems, fmt.Sprintf("%s:%d: expected KEY=VALUE", path, line))
+
+			continue
+		}
+
+		entries = append(entries, Entry{Line: line, Key: strings.TrimSpace(key), Value: unquote(value)})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	if len(lineErr.Problems) > 0 {
+		return entries, lineErr
+	}
+
+	return entries, nil
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// Get returns the value of the option as it would be written in the config
+// file.
+func (c *Config) Get(key string) (string, error) {
+	field, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+
+	if duration, ok := field.Interface().(time.Duration); ok {
+		return duration.String(), nil
+	}
+
+	return fmt.Sprint(field.Interface()), nil
+}
+
+func (c *Config) Set(key string, value string) error {
+	field, err := c.field(key)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := field.Interface().(time.Duration); ok {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration such as 30s or 5m, got '%s'", key, value)
+		}
+
+		field.SetInt(int64(duration))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, got '%s'", key, value)
+		}
+
+		field.SetBool(b)
+
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			return fmt.Errorf("%s must be a number of at least 0, got '%s'", key, value)
+		}
+
+		field.SetInt(int64(i))
+
+	default:
+		field.SetString(value)
+	}
+
+	return nil
+}
+
+func isOption(key string) bool {
+	for _, o := range options {
+		if o.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Config) field(key string) (reflect.Value, error) {
+	for _, o := range options {
+		if o.Key == key {
+			return reflect.ValueOf(c).Elem().FieldByName(o.Field), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("unknown key '%s'", key)
+}
+
+// Save sets the key in the config file at path, keeping the rest of the file
+// as it is. A commented-out line for the key, as written by 'clx config init',
+// is replaced, otherwise the key is appended.
+func Save(path string, key string, value string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	line := key + "=" + Quote(value)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	commented := -1
+
+	for i, l := range lines {
+		text := strings.TrimSpace(l)
+
+		if strings.HasPrefix(strings.TrimPrefix(text, "export "), key+"=") {
+			lines[i] = line
+
+			return writeLines(path, lines)
+		}
+
+		if strings.HasPrefix(text, "# "+key+"=") && commented == -1 {
+			commented = i
+		}
+	}
+
+	if commented != -1 {
+		lines[commented] = line
+	} else {
+		lines = append(lines, line)
+	}
+
+	return writeLines(path, lines)
+}
+
+func writeLines(path string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.TrimLeft(strings.Join(lines, "\n"), "\n")+"\n"), 0o600); err != nil {
+		return fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Quote wraps values that would otherwise lose leading or trailing spaces.
+func Quote(value string) string {
+	if strings.TrimSpace(value) != value {
+		return `"` + value + `"`
+	}
+
+	return value
+}
@@ -0,0 +1,112 @@
+package settings_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"clx/settings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.env")
+	content := `# Comment
+COMMENT_WIDTH=90
+export TIMEOUT=20s
+INDENTATION_SYMBOL=" | "
+BACKEND=firebase
+`
+
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	config, err := settings.Load(configPath, []string{"CLX_BACKEND=algolia", "PATH=/bin"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 90, config.CommentWidth)
+	assert.Equal(t, 20*time.Second, config.Timeout)
+	assert.Equal(t, " | ", config.IndentationSymbol)
+	assert.Equal(t, "algolia", config.Backend)
+	assert.Equal(t, settings.Default().UserAgent, config.UserAgent)
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.env")
+	content := "COMMENT_WIDTH=wide\nUNKNOWN=1\nNERDFONTS=true\n"
+
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	config, err := settings.Load(configPath, []string{"CLX_AUTO_REFRESH=often", "CLX_UNKNOWN=1"})
+
+	var loadErr *settings.LoadError
+
+	assert.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, []string{
+		configPath + ":1: COMMENT_WIDTH must be a number of at least 0, got 'wide'",
+		configPath + ":2: unknown key 'UNKNOWN'",
+		"environment variable CLX_AUTO_REFRESH: AUTO_REFRESH must be a duration such as 30s or 5m, got 'often'",
+	}, loadErr.Problems)
+	assert.True(t, config.EnableNerdFonts)
+	assert.Equal(t, settings.Default().CommentWidth, config.CommentWidth)
+}
+
+func TestUnknownVariables(t *testing.T) {
+	t.Parallel()
+
+	environ := []string{"CLX_COMMENT_WIDTH=80", "CLX_COMENT_WIDTH=80", "PATH=/bin"}
+
+	_, err := settings.Load(filepath.Join(t.TempDir(), "config.env"), environ)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CLX_COMENT_WIDTH"}, settings.UnknownVariables(environ))
+}
+
+func TestOptionsCoverConfig(t *testing.T) {
+	t.Parallel()
+
+	config := settings.Default()
+
+	for _, option := range settings.Options() {
+		_, err := config.Get(option.Key)
+		assert.NoError(t, err)
+	}
+
+	// Every field but the lesskey paths, which are set at runtime
+	assert.Len(t, settings.Options(), reflect.TypeOf(settings.Config{}).NumField()-2)
+}
+
+func TestIndentationSymbol(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, " | ", settings.IndentationSymbol(" | ", false))
+	assert.Equal(t, " ", settings.IndentationSymbol(" | ", true))
+	assert.NotEqual(t, settings.Default().IndentationSymbol,
+		settings.IndentationSymbol(settings.Default().IndentationSymbol, false))
+}
+
+func TestSave(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.env")
+	content := "# set the comment width\n# COMMENT_WIDTH=70\nexport NERDFONTS=false\n"
+
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	assert.NoError(t, settings.Save(configPath, "COMMENT_WIDTH", "80"))
+	assert.NoError(t, settings.Save(configPath, "NERDFONTS", "true"))
+	assert.NoError(t, settings.Save(configPath, "INDENTATION_SYMBOL", " | "))
+
+	saved, err := os.ReadFile(configPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "# set the comment width\nCOMMENT_WIDTH=80\nNERDFONTS=true\nINDENTATION_SYMBOL=\" | \"\n",
+		string(saved))
+}
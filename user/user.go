@@ -0,0 +1,38 @@
+package user
+
+import (
+	"regexp"
+
+	"github.com/nleeper/goment"
+)
+
+// validName matches the usernames that Hacker News allows
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]{2,15}$`)
+
+type User struct {
+	Name    string
+	Created int64
+	Karma   int
+	About   string
+}
+
+// IsValidName reports whether name can be a username on Hacker News. Names
+// are used in URLs and file names, so others must not be fetched.
+func IsValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// JoinedAgo returns the age of the account, e.g. '12 years ago'.
+func (u *User) JoinedAgo() string {
+	moment, err := goment.Unix(u.Created)
+	if err != nil {
+		return ""
+	}
+
+	now, err := goment.New()
+	if err != nil {
+		return ""
+	}
+
+	return moment.From(now)
+}
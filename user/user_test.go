@@ -0,0 +1,21 @@
+package user_test
+
+import (
+	"testing"
+
+	"clx/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"pg", "dang", "some_user-1", "abcdefghijklmno"} {
+		assert.True(t, user.IsValidName(name), name)
+	}
+
+	for _, name := range []string{"", "a", "abcdefghijklmnop", "../session", "a/b", "pg?id=1", "a b"} {
+		assert.False(t, user.IsValidName(name), name)
+	}
+}
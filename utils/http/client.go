@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"clx/app"
+	"clx/hn"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type Options struct {
+	Timeout               time.Duration
+	UserAgent             string
+	Proxy                 string
+	MaxRetries            int
+	MaxConcurrentRequests int
+	RequestsPerSecond     int
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Timeout:               10 * time.Second,
+		UserAgent:             app.Name + "/" + app.Version,
+		MaxRetries:            2,
+		MaxConcurrentRequests: 16,
+	}
+}
+
+var (
+	mu            sync.Mutex
+	client        *resty.Client
+	sessionClient *resty.Client
+	limiter       *rateLimiter
+)
+
+// Configure replaces the shared client. Requests that are already in flight
+// finish on the old client.
+func Configure(options Options) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	client, sessionClient, limiter = newClient(options), newSessionClient(options), newRateLimiter(options)
+}
+
+func getClient() (*resty.Client, *rateLimiter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	initClients()
+
+	return client, limiter
+}
+
+func getSessionClient() (*resty.Client, *rateLimiter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	initClients()
+
+	return sessionClient, limiter
+}
+
+func initClients() {
+	if client == nil {
+		options := DefaultOptions()
+		client, sessionClient, limiter = newClient(options), newSessionClient(options), newRateLimiter(options)
+	}
+}
+
+func newClient(options Options) *resty.Client {
+	c := resty.New().
+		SetTimeout(options.Timeout).
+		SetHeader("User-Agent", options.UserAgent).
+		SetRetryCount(options.MaxRetries).
+		SetRetryWaitTime(250 * time.Millisecond).
+		SetRetryMaxWaitTime(3 * time.Second).
+		AddRetryCondition(isRetryable)
+
+	if options.Proxy != "" {
+		c.SetProxy(options.Proxy)
+	}
+
+	return c
+}
+
+// newSessionClient returns a client for requests that are made on behalf of
+// a logged-in user. It neither follows redirects nor keeps cookies, so the
+// caller sees the cookies set by the login redirect and decides which cookies
+// are sent. Requests are not retried, since Hacker News may have accepted a
+// reply or submission before the response failed.
+func newSessionClient(options Options) *resty.Client {
+	return newClient(options).
+		SetRetryCount(0).
+		SetCookieJar(nil).
+		SetRedirectPolicy(resty.RedirectPolicyFunc(func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}))
+}
+
+// isRetryable retries server errors and timeouts. The backoff between
+// attempts is exponential with jitter.
+func isRetryable(resp *resty.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	return resp != nil && resp.StatusCode() >= http.StatusInternalServerError
+}
+
+// Get fetches url and decodes the JSON response into result. Errors are
+// classified into the typed errors from the hn package.
+func Get(ctx context.Context, url string, result interface{}) error {
+	c, l := getClient()
+
+	if err := l.acquire(ctx); err != nil {
+		return hn.ClassifyError(0, err)
+	}
+	defer l.release()
+
+	resp, err := c.R().
+		SetContext(ctx).
+		ForceContentType("application/json").
+		SetResult(result).
+		Get(url)
+
+	return hn.ClassifyError(statusCode(resp), err)
+}
+
+// GetPage fetches url and returns the raw body together with its content
+// type.
+func GetPage(ctx context.Context, url string) ([]byte, string, error) {
+	c, l := getClient()
+
+	if err := l.acquire(ctx); err != nil {
+		return nil, "", hn.ClassifyError(0, err)
+	}
+	defer l.release()
+
+	resp, err := c.R().
+		SetContext(ctx).
+		Get(url)
+	if err := hn.ClassifyError(statusCode(resp), err); err != nil {
+		return nil, "", fmt.Errorf("could not fetch page: %w", err)
+	}
+
+	return resp.Body(), resp.Header().Get("Content-Type"), nil
+}
+
+func statusCode(resp *resty.Response) int {
+	if resp == nil {
+		return 0
+	}
+
+	return resp.StatusCode()
+}
+
+// rateLimiter bounds the number of requests in flight and spaces out the
+// start of each request if a rate is set.
+type rateLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(options Options) *rateLimiter {
+	l := &rateLimiter{slots: make(chan struct{}, max(1, options.MaxConcurrentRequests))}
+
+	if options.RequestsPerSecond > 0 {
+		l.interval = time.Second / time.Duration(options.RequestsPerSecond)
+	}
+
+	return l
+}
+
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := now
+	if l.next.After(now) {
+		start = l.next
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(start.Sub(now)):
+		return nil
+	case <-ctx.Done():
+		l.release()
+
+		return ctx.Err()
+	}
+}
+
+func (l *rateLimiter) release() {
+	<-l.slots
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,84 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"clx/hn"
+	"clx/utils/http"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(nethttp.StatusServiceUnavailable)
+
+			return
+		}
+
+		assert.Equal(t, "clx-test", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	options := http.DefaultOptions()
+	options.UserAgent = "clx-test"
+	http.Configure(options)
+
+	var result struct {
+		ID int `json:"id"`
+	}
+
+	err := http.Get(context.Background(), server.URL, &result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		attempts.Add(1)
+		w.WriteHeader(nethttp.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	http.Configure(http.DefaultOptions())
+
+	err := http.Get(context.Background(), server.URL, new(struct{}))
+
+	assert.True(t, errors.Is(err, hn.ErrRateLimited))
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestPostFormIsNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(nethttp.StatusBadGateway)
+
+			return
+		}
+
+		w.WriteHeader(nethttp.StatusFound)
+	}))
+	defer server.Close()
+
+	http.Configure(http.DefaultOptions())
+
+	_, err := http.PostForm(context.Background(), server.URL+"/comment", map[string]string{"text": "Reply"}, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
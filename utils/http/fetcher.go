@@ -1,15 +1,12 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"strconv"
-	"time"
 
-	"clx/app"
 	"clx/constants/category"
 	"clx/endpoints"
-
-	"github.com/go-resty/resty/v2"
 )
 
 const (
@@ -23,14 +20,7 @@ func FetchStories(page int, category int) ([]*endpoints.Story, error) {
 
 	var s []*endpoints.Story
 
-	client := resty.New()
-	client.SetTimeout(5 * time.Second)
-
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(&s).
-		Get(url + p)
-	if err != nil {
+	if err := Get(context.Background(), url+p, &s); err != nil {
 		return nil, fmt.Errorf("could not fetch stories: %w", err)
 	}
 
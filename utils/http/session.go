@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"clx/hn"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Response is the raw response to a request made with PostForm or
+// GetWithCookies.
+type Response struct {
+	StatusCode int
+	Body       []byte
+	Cookies    []*http.Cookie
+	Location   string
+}
+
+// PostForm submits form to url. Redirects are returned rather than followed.
+func PostForm(ctx context.Context, url string, form map[string]string, cookies []*http.Cookie) (*Response, error) {
+	return doSessionRequest(ctx, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetFormData(form).SetCookies(cookies).Post(url)
+	})
+}
+
+// GetWithCookies fetches url with cookies. Redirects are returned rather than
+// followed.
+func GetWithCookies(ctx context.Context, url string, cookies []*http.Cookie) (*Response, error) {
+	return doSessionRequest(ctx, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetCookies(cookies).Get(url)
+	})
+}
+
+func doSessionRequest(ctx context.Context, do func(r *resty.Request) (*resty.Response, error)) (*Response, error) {
+	c, l := getSessionClient()
+
+	if err := l.acquire(ctx); err != nil {
+		return nil, hn.ClassifyError(0, err)
+	}
+	defer l.release()
+
+	resp, err := do(c.R().SetContext(ctx))
+	if err := hn.ClassifyError(statusCode(resp), err); err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode(),
+		Body:       resp.Body(),
+		Cookies:    resp.Cookies(),
+		Location:   resp.Header().Get("Location"),
+	}, nil
+}